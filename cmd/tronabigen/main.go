@@ -0,0 +1,103 @@
+// Command tronabigen generates a typed Go binding of a TRON contract.
+//
+//	tronabigen -abi token.abi -pkg token -type Token -out token.go
+//	tronabigen -contract TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t -node grpc.trongrid.io:50051 -pkg usdt -type USDT
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/bind"
+	"github.com/craftto/go-tron/pkg/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	var (
+		abiFile      = flag.String("abi", "", "path of the contract ABI JSON, - for stdin")
+		contractAddr = flag.String("contract", "", "base58 address of a deployed contract to fetch the ABI from")
+		node         = flag.String("node", "grpc.trongrid.io:50051", "gRPC node used with -contract")
+		apiKey       = flag.String("apikey", "", "TRON-PRO-API-KEY used with -contract")
+		pkg          = flag.String("pkg", "", "package name of the generated code")
+		typeName     = flag.String("type", "", "type name of the binding, defaults to the package name")
+		out          = flag.String("out", "", "output file, defaults to stdout")
+	)
+	flag.Parse()
+
+	if *pkg == "" || (*abiFile == "") == (*contractAddr == "") {
+		fmt.Fprintln(os.Stderr, "usage: tronabigen (-abi file | -contract address) -pkg name [-type name] [-out file]")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	if *typeName == "" {
+		*typeName = *pkg
+	}
+
+	if err := run(*abiFile, *contractAddr, *node, *apiKey, *pkg, *typeName, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "tronabigen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(abiFile, contractAddr, node, apiKey, pkg, typeName, out string) error {
+	var abiJSON string
+
+	switch {
+	case abiFile == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		abiJSON = string(data)
+	case abiFile != "":
+		data, err := os.ReadFile(abiFile)
+		if err != nil {
+			return err
+		}
+		abiJSON = string(data)
+	default:
+		var err error
+		if abiJSON, err = fetchABI(contractAddr, node, apiKey); err != nil {
+			return err
+		}
+	}
+
+	code, err := bind.Generate(abiJSON, pkg, typeName)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+
+	return os.WriteFile(out, code, 0644)
+}
+
+func fetchABI(contractAddr, node, apiKey string) (string, error) {
+	g, err := client.NewGrpcClient(node, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer g.Close()
+
+	if apiKey != "" {
+		if err := g.SetAPIKey(apiKey); err != nil {
+			return "", err
+		}
+	}
+
+	smartContractABI, err := g.GetContractABI(contractAddr)
+	if err != nil {
+		return "", err
+	}
+
+	return abi.SmartContractABIToJSON(smartContractABI)
+}
@@ -0,0 +1,470 @@
+package abi
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var bigIntType = reflect.TypeOf(&big.Int{})
+
+// ConvertArguments converts values to the Go types packed by args,
+// see ToEVM
+func ConvertArguments(args abi.Arguments, values []interface{}) ([]interface{}, error) {
+	if len(args) != len(values) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(args), len(values))
+	}
+
+	result := make([]interface{}, 0, len(values))
+	for i, arg := range args {
+		path := arg.Name
+		if path == "" {
+			path = fmt.Sprintf("argument %d", i)
+		}
+
+		v, err := toEVM(arg.Type, values[i], path)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+// ConvertOutputs converts values unpacked by go-ethereum, see FromEVM
+func ConvertOutputs(args abi.Arguments, values []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for i, v := range values {
+		if i < len(args) {
+			v = FromEVM(args[i].Type, v)
+		}
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// ToEVM converts v to the Go value go-ethereum packs for ty.
+// Besides the go-ethereum types it accepts the JSON friendly forms:
+//   - address: address.Address or a string in any form of address.Parse
+//   - integers: Go integers, *big.Int, float64 and json.Number without
+//     fraction, decimal or 0x hex strings
+//   - bool: bool or "true" and "false"
+//   - bytes: []byte, byte arrays, hex strings with or without 0x, base64 strings
+//   - arrays and slices: any slice or array of convertible values
+//   - tuples: []interface{} in component order, map[string]interface{} by
+//     component name or any struct with the component fields
+func ToEVM(ty abi.Type, v interface{}) (interface{}, error) {
+	return toEVM(ty, v, "value")
+}
+
+func toEVM(ty abi.Type, v interface{}, path string) (interface{}, error) {
+	if v == nil {
+		return nil, fmt.Errorf("%s: missing %s value", path, ty.String())
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+
+	switch ty.T {
+	case abi.AddressTy:
+		result, err = toEVMAddress(v)
+	case abi.IntTy, abi.UintTy:
+		result, err = toEVMInt(ty, v)
+	case abi.BoolTy:
+		result, err = toEVMBool(v)
+	case abi.StringTy:
+		s, ok := v.(string)
+		if !ok {
+			err = fmt.Errorf("expected a string, got %T", v)
+		}
+		result = s
+	case abi.BytesTy, abi.FixedBytesTy:
+		result, err = toEVMBytes(ty, v)
+	case abi.SliceTy, abi.ArrayTy:
+		return toEVMList(ty, v, path)
+	case abi.TupleTy:
+		return toEVMTuple(ty, v, path)
+	default:
+		result = v
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return result, nil
+}
+
+// FromEVM converts a value unpacked by go-ethereum for ty, replacing
+// EVM addresses by address.Address at any depth of lists and tuples.
+// Tuples are returned as structs with the same fields
+func FromEVM(ty abi.Type, v interface{}) interface{} {
+	if v == nil || !hasAddress(ty) {
+		return v
+	}
+
+	out, ok := fromEVM(ty, reflect.ValueOf(v))
+	if !ok {
+		return v
+	}
+
+	return out.Interface()
+}
+
+func fromEVM(ty abi.Type, v reflect.Value) (reflect.Value, bool) {
+	if !hasAddress(ty) {
+		return v, true
+	}
+
+	switch ty.T {
+	case abi.AddressTy:
+		addr, ok := v.Interface().(common.Address)
+		if !ok {
+			return v, false
+		}
+		return reflect.ValueOf(address.Address(append([]byte{address.TronBytePrefix}, addr.Bytes()...))), true
+	case abi.SliceTy, abi.ArrayTy:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return v, false
+		}
+
+		var out reflect.Value
+		if ty.T == abi.SliceTy {
+			out = reflect.MakeSlice(tronGoType(ty), v.Len(), v.Len())
+		} else {
+			if v.Len() != ty.Size {
+				return v, false
+			}
+			out = reflect.New(tronGoType(ty)).Elem()
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			elem, ok := fromEVM(*ty.Elem, v.Index(i))
+			if !ok {
+				return v, false
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, true
+	case abi.TupleTy:
+		if v.Kind() != reflect.Struct || v.NumField() != len(ty.TupleElems) {
+			return v, false
+		}
+
+		out := reflect.New(tronGoType(ty)).Elem()
+		for i, elem := range ty.TupleElems {
+			field, ok := fromEVM(*elem, v.Field(i))
+			if !ok {
+				return v, false
+			}
+			out.Field(i).Set(field)
+		}
+		return out, true
+	}
+
+	return v, true
+}
+
+// tronGoType returns the Go type returned by FromEVM for ty
+func tronGoType(ty abi.Type) reflect.Type {
+	if !hasAddress(ty) {
+		return ty.GetType()
+	}
+
+	switch ty.T {
+	case abi.AddressTy:
+		return reflect.TypeOf(address.Address{})
+	case abi.SliceTy:
+		return reflect.SliceOf(tronGoType(*ty.Elem))
+	case abi.ArrayTy:
+		return reflect.ArrayOf(ty.Size, tronGoType(*ty.Elem))
+	case abi.TupleTy:
+		fields := make([]reflect.StructField, 0, len(ty.TupleElems))
+		for i, elem := range ty.TupleElems {
+			field := ty.TupleType.Field(i)
+			field.Type = tronGoType(*elem)
+			fields = append(fields, field)
+		}
+		return reflect.StructOf(fields)
+	}
+
+	return ty.GetType()
+}
+
+func hasAddress(ty abi.Type) bool {
+	switch ty.T {
+	case abi.AddressTy:
+		return true
+	case abi.SliceTy, abi.ArrayTy:
+		return hasAddress(*ty.Elem)
+	case abi.TupleTy:
+		for _, elem := range ty.TupleElems {
+			if hasAddress(*elem) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func toEVMAddress(v interface{}) (common.Address, error) {
+	switch addr := v.(type) {
+	case common.Address:
+		return addr, nil
+	case address.Address:
+		if !addr.IsValid() {
+			return common.Address{}, fmt.Errorf("invalid address %x", []byte(addr))
+		}
+		return common.BytesToAddress(addr[1:]), nil
+	case string:
+		tronAddr, err := address.Parse(addr)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return common.BytesToAddress(tronAddr[1:]), nil
+	}
+
+	return common.Address{}, fmt.Errorf("invalid address %v", v)
+}
+
+func toEVMInt(ty abi.Type, v interface{}) (interface{}, error) {
+	target := ty.GetType()
+	rv := reflect.ValueOf(v)
+
+	var n *big.Int
+	switch value := v.(type) {
+	case *big.Int:
+		if value == nil {
+			return nil, fmt.Errorf("nil integer")
+		}
+		n = value
+	case string:
+		var ok bool
+		s := strings.TrimSpace(value)
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			n, ok = new(big.Int).SetString(s[2:], 16)
+		} else {
+			n, ok = new(big.Int).SetString(s, 10)
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", value)
+		}
+	case json.Number:
+		var ok bool
+		if n, ok = new(big.Int).SetString(value.String(), 10); !ok {
+			return nil, fmt.Errorf("invalid integer %s", value)
+		}
+	case float64:
+		if value != math.Trunc(value) || math.IsInf(value, 0) {
+			return nil, fmt.Errorf("invalid integer %v", value)
+		}
+		n, _ = new(big.Float).SetFloat64(value).Int(nil)
+	default:
+		switch {
+		case rv.CanInt():
+			n = big.NewInt(rv.Int())
+		case rv.CanUint():
+			n = new(big.Int).SetUint64(rv.Uint())
+		default:
+			return nil, fmt.Errorf("expected an integer, got %T", v)
+		}
+	}
+
+	if ty.T == abi.UintTy {
+		if n.Sign() < 0 {
+			return nil, fmt.Errorf("negative integer %s for %s", n, ty.String())
+		}
+		if n.BitLen() > ty.Size {
+			return nil, fmt.Errorf("integer %s overflows %s", n, ty.String())
+		}
+	} else {
+		// signed integers range from -2^(size-1) to 2^(size-1)-1
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(ty.Size-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("integer %s overflows %s", n, ty.String())
+		}
+	}
+
+	if target == bigIntType {
+		return n, nil
+	}
+
+	out := reflect.New(target).Elem()
+	if ty.T == abi.IntTy {
+		if !n.IsInt64() || out.OverflowInt(n.Int64()) {
+			return nil, fmt.Errorf("integer %s overflows %s", n, ty.String())
+		}
+		out.SetInt(n.Int64())
+	} else {
+		if !n.IsUint64() || out.OverflowUint(n.Uint64()) {
+			return nil, fmt.Errorf("integer %s overflows %s", n, ty.String())
+		}
+		out.SetUint(n.Uint64())
+	}
+
+	return out.Interface(), nil
+}
+
+func toEVMBool(v interface{}) (bool, error) {
+	switch b := v.(type) {
+	case bool:
+		return b, nil
+	case string:
+		return strconv.ParseBool(b)
+	}
+
+	return false, fmt.Errorf("expected a bool, got %T", v)
+}
+
+func toEVMBytes(ty abi.Type, v interface{}) (interface{}, error) {
+	var data []byte
+
+	switch value := v.(type) {
+	case []byte:
+		data = value
+	case string:
+		var err error
+		s := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+		// convert from hex string
+		if data, err = hex.DecodeString(s); err != nil {
+			// try with base64
+			if data, err = base64.StdEncoding.DecodeString(value); err != nil {
+				return nil, fmt.Errorf("invalid bytes %q", value)
+			}
+		}
+	default:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Uint8 {
+			return nil, fmt.Errorf("expected bytes, got %T", v)
+		}
+		data = make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(data), rv)
+	}
+
+	if ty.T == abi.BytesTy {
+		return data, nil
+	}
+
+	if len(data) != ty.Size {
+		return nil, fmt.Errorf("invalid size: %d/%d", ty.Size, len(data))
+	}
+
+	out := reflect.New(ty.GetType()).Elem()
+	reflect.Copy(out, reflect.ValueOf(data))
+
+	return out.Interface(), nil
+}
+
+func toEVMList(ty abi.Type, v interface{}, path string) (interface{}, error) {
+	target := ty.GetType()
+	rv := reflect.ValueOf(v)
+
+	if rv.Type() == target {
+		return v, nil
+	}
+
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%s: expected a list for %s, got %T", path, ty.String(), v)
+	}
+
+	var out reflect.Value
+	if ty.T == abi.SliceTy {
+		out = reflect.MakeSlice(target, rv.Len(), rv.Len())
+	} else {
+		if rv.Len() != ty.Size {
+			return nil, fmt.Errorf("%s: expected %d elements for %s, got %d", path, ty.Size, ty.String(), rv.Len())
+		}
+		out = reflect.New(target).Elem()
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		elem, err := toEVM(*ty.Elem, rv.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+		out.Index(i).Set(reflect.ValueOf(elem))
+	}
+
+	return out.Interface(), nil
+}
+
+func toEVMTuple(ty abi.Type, v interface{}, path string) (interface{}, error) {
+	target := ty.GetType()
+	rv := reflect.ValueOf(v)
+
+	if rv.Type() == target {
+		return v, nil
+	}
+
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	// component returns the value of the i-th tuple component in v
+	var component func(i int) (interface{}, bool)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Len() != len(ty.TupleElems) {
+			return nil, fmt.Errorf("%s: expected %d tuple components, got %d", path, len(ty.TupleElems), rv.Len())
+		}
+		component = func(i int) (interface{}, bool) {
+			return rv.Index(i).Interface(), true
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%s: expected string keys for a tuple, got %T", path, v)
+		}
+		component = func(i int) (interface{}, bool) {
+			for _, name := range []string{ty.TupleRawNames[i], abi.ToCamelCase(ty.TupleRawNames[i])} {
+				value := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+				if value.IsValid() {
+					return value.Interface(), true
+				}
+			}
+			return nil, false
+		}
+	case reflect.Struct:
+		component = func(i int) (interface{}, bool) {
+			field := rv.FieldByName(abi.ToCamelCase(ty.TupleRawNames[i]))
+			if !field.IsValid() || !field.CanInterface() {
+				return nil, false
+			}
+			return field.Interface(), true
+		}
+	default:
+		return nil, fmt.Errorf("%s: expected a tuple for %s, got %T", path, ty.String(), v)
+	}
+
+	out := reflect.New(target).Elem()
+	for i, elem := range ty.TupleElems {
+		name := ty.TupleRawNames[i]
+		value, ok := component(i)
+		if !ok {
+			return nil, fmt.Errorf("%s: missing tuple component %s", path, name)
+		}
+
+		converted, err := toEVM(*elem, value, path+"."+name)
+		if err != nil {
+			return nil, err
+		}
+		out.Field(i).Set(reflect.ValueOf(converted))
+	}
+
+	return out.Interface(), nil
+}
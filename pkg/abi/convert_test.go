@@ -0,0 +1,44 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestToEVMIntRange(t *testing.T) {
+	pow := func(n uint) *big.Int { return new(big.Int).Lsh(big.NewInt(1), n) }
+	sub := func(a *big.Int, b int64) *big.Int { return new(big.Int).Sub(a, big.NewInt(b)) }
+
+	tests := []struct {
+		typ   string
+		value interface{}
+		ok    bool
+	}{
+		{"uint256", big.NewInt(-1), false},
+		{"uint256", sub(pow(256), 1), true},
+		{"uint256", pow(256), false},
+		{"int256", sub(pow(255), 1), true},
+		{"int256", pow(255), false},
+		{"int256", new(big.Int).Neg(pow(255)), true},
+		{"int256", sub(new(big.Int).Neg(pow(255)), 1), false},
+		{"int8", big.NewInt(-128), true},
+		{"int8", big.NewInt(128), false},
+		{"int8", int8(-128), true},
+		{"uint8", big.NewInt(256), false},
+		{"uint8", uint8(255), true},
+		{"uint64", int64(-1), false},
+		{"uint256", nil, false},
+	}
+
+	for _, test := range tests {
+		ty, err := NewType(test.typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ToEVM(ty, test.value)
+		if (err == nil) != test.ok {
+			t.Errorf("%s %v: got error %v, want ok %v", test.typ, test.value, err, test.ok)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrEventNotFound is returned when no event of the ABI matches a log
+var ErrEventNotFound = errors.New("event not found")
+
+// Event is a decoded contract event log
+type Event struct {
+	// Address is the contract which emitted the event
+	Address   address.Address
+	Name      string
+	Signature string
+	// Args holds the arguments by name, unnamed arguments are named arg0, arg1...
+	Args map[string]interface{}
+	// Values holds the arguments in declaration order.
+	// Indexed strings, bytes, arrays and tuples are only known by their hash
+	Values []interface{}
+}
+
+// Scan assigns the event values in declaration order to the targets,
+// pointers to variables of the value types
+func (e *Event) Scan(targets ...interface{}) error {
+	if len(e.Values) != len(targets) {
+		return fmt.Errorf("%s: expected %d values, got %d", e.Signature, len(targets), len(e.Values))
+	}
+
+	for i, target := range targets {
+		rv := reflect.ValueOf(target)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return fmt.Errorf("%s: target %d is not a pointer", e.Signature, i)
+		}
+
+		value := reflect.ValueOf(e.Values[i])
+		if !value.IsValid() || !value.Type().AssignableTo(rv.Elem().Type()) {
+			return fmt.Errorf("%s: cannot assign value %d of type %T to %s", e.Signature, i, e.Values[i], rv.Elem().Type())
+		}
+		rv.Elem().Set(value)
+	}
+
+	return nil
+}
+
+// DecodeLog decodes the log emitted by contractAddr with the matching event of a.
+// When a is nil the StandardABIs are used
+func DecodeLog(a *abi.ABI, contractAddr []byte, topics [][]byte, data []byte) (*Event, error) {
+	if a == nil {
+		for _, standard := range StandardABIs {
+			event, err := DecodeLog(standard, contractAddr, topics, data)
+			if !errors.Is(err, ErrEventNotFound) {
+				return event, err
+			}
+		}
+		return nil, ErrEventNotFound
+	}
+
+	if len(topics) == 0 {
+		return nil, ErrEventNotFound
+	}
+
+	for _, e := range a.Events {
+		if e.Anonymous || !bytes.Equal(e.ID.Bytes(), topics[0]) {
+			continue
+		}
+
+		indexed := 0
+		for _, input := range e.Inputs {
+			if input.Indexed {
+				indexed++
+			}
+		}
+		// TRC20 and TRC721 Transfer share their signature but not their indexed arguments
+		if indexed != len(topics)-1 {
+			continue
+		}
+
+		return decodeEvent(e, contractAddr, topics[1:], data)
+	}
+
+	return nil, ErrEventNotFound
+}
+
+func decodeEvent(e abi.Event, contractAddr []byte, topics [][]byte, data []byte) (*Event, error) {
+	nonIndexed := e.Inputs.NonIndexed()
+
+	unpacked, err := nonIndexed.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", e.Sig, err)
+	}
+	unpacked = ConvertOutputs(nonIndexed, unpacked)
+
+	event := &Event{
+		Address:   address.EVMBytesToAddress(contractAddr),
+		Name:      e.RawName,
+		Signature: e.Sig,
+		Args:      make(map[string]interface{}),
+		Values:    make([]interface{}, 0, len(e.Inputs)),
+	}
+
+	for i, input := range e.Inputs {
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+
+		var value interface{}
+		if input.Indexed {
+			topic := topics[0]
+			topics = topics[1:]
+
+			if value, err = decodeTopic(input.Type, topic); err != nil {
+				return nil, fmt.Errorf("%s: %s: %v", e.Sig, name, err)
+			}
+		} else {
+			value = unpacked[0]
+			unpacked = unpacked[1:]
+		}
+
+		event.Args[name] = value
+		event.Values = append(event.Values, value)
+	}
+
+	return event, nil
+}
+
+func decodeTopic(ty abi.Type, topic []byte) (interface{}, error) {
+	if len(topic) != common.HashLength {
+		return nil, fmt.Errorf("invalid topic length %d", len(topic))
+	}
+
+	switch ty.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		// dynamic values are replaced by their keccak256 hash in topics
+		return common.BytesToHash(topic), nil
+	}
+
+	args := abi.Arguments{{Type: ty}}
+	values, err := args.Unpack(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromEVM(ty, values[0]), nil
+}
@@ -0,0 +1,270 @@
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// logFixture is a log as returned by the nodes in the transaction info,
+// with the 20 bytes address of the emitting contract
+type logFixture struct {
+	address string
+	topics  []string
+	data    string
+}
+
+func (f logFixture) decode(t *testing.T, a *abi.ABI) (*Event, error) {
+	t.Helper()
+
+	topics := make([][]byte, 0, len(f.topics))
+	for _, topic := range f.topics {
+		topics = append(topics, mustDecodeHex(t, topic))
+	}
+
+	return DecodeLog(a, mustDecodeHex(t, f.address), topics, mustDecodeHex(t, f.data))
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+const (
+	transferTopic = "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	usdtEVM       = "a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	senderEVM     = "3b32a0ae2f5d4d9f2f8c7bc3a0d75b1e2a8dc0a4"
+	receiverEVM   = "e5e4a7ac8b6c1d53f2f2a9e0c1b7a92d5b1a0f11"
+)
+
+// a USDT transfer of 12.5 USDT
+var trc20Transfer = logFixture{
+	address: usdtEVM,
+	topics: []string{
+		transferTopic,
+		"000000000000000000000000" + senderEVM,
+		"000000000000000000000000" + receiverEVM,
+	},
+	data: "0000000000000000000000000000000000000000000000000000000000bebc20",
+}
+
+// a TRC721 transfer of the token 1024
+var trc721Transfer = logFixture{
+	address: "4c7f4d7f7f4c6f1d0d2ef4a0f2c6e0d3a8f1b2c3",
+	topics: []string{
+		transferTopic,
+		"000000000000000000000000" + senderEVM,
+		"000000000000000000000000" + receiverEVM,
+		"0000000000000000000000000000000000000000000000000000000000000400",
+	},
+}
+
+func tronAddress(t *testing.T, evm string) address.Address {
+	return append([]byte{address.TronBytePrefix}, mustDecodeHex(t, evm)...)
+}
+
+func TestDecodeLogTRC20(t *testing.T) {
+	event, err := trc20Transfer.decode(t, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event.Name != "Transfer" || event.Signature != "Transfer(address,address,uint256)" {
+		t.Errorf("event = %s %s", event.Name, event.Signature)
+	}
+	if event.Address.String() != "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t" {
+		t.Errorf("Address = %s, want the 0x41 prefixed contract address", event.Address)
+	}
+
+	var (
+		from, to address.Address
+		value    *big.Int
+	)
+	if err := event.Scan(&from, &to, &value); err != nil {
+		t.Fatal(err)
+	}
+	if from.Hex() != tronAddress(t, senderEVM).Hex() || to.Hex() != tronAddress(t, receiverEVM).Hex() {
+		t.Errorf("from, to = %s, %s", from.Hex(), to.Hex())
+	}
+	if value.Cmp(big.NewInt(12_500_000)) != 0 {
+		t.Errorf("value = %s", value)
+	}
+	if event.Args["value"].(*big.Int).Cmp(value) != 0 || event.Args["to"].(address.Address).Hex() != to.Hex() {
+		t.Errorf("Args = %v", event.Args)
+	}
+
+	// the 0x41 prefixed addresses are kept
+	topics := [][]byte{mustDecodeHex(t, transferTopic), mustDecodeHex(t, trc20Transfer.topics[1]), mustDecodeHex(t, trc20Transfer.topics[2])}
+	prefixed, err := DecodeLog(nil, tronAddress(t, usdtEVM), topics, mustDecodeHex(t, trc20Transfer.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prefixed.Address.Hex() != event.Address.Hex() {
+		t.Errorf("Address = %s, want %s", prefixed.Address.Hex(), event.Address.Hex())
+	}
+}
+
+func TestDecodeLogTRC721(t *testing.T) {
+	// the TRC721 Transfer shares the TRC20 signature with the token id indexed
+	event, err := trc721Transfer.decode(t, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(event.Values) != 3 {
+		t.Fatalf("Values = %v", event.Values)
+	}
+	tokenID, ok := event.Args["tokenId"].(*big.Int)
+	if !ok || tokenID.Int64() != 1024 {
+		t.Errorf("tokenId = %v", event.Args["tokenId"])
+	}
+	if from := event.Args["from"].(address.Address); from.Hex() != tronAddress(t, senderEVM).Hex() {
+		t.Errorf("from = %s", from.Hex())
+	}
+	if !strings.HasPrefix(event.Address.Hex(), "0x41") {
+		t.Errorf("Address = %s", event.Address.Hex())
+	}
+
+	// with the TRC20 ABI only, the fourth topic does not match any event
+	if _, err := trc721Transfer.decode(t, TRC20); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("DecodeLog(TRC20) = %v, want %v", err, ErrEventNotFound)
+	}
+}
+
+const customABI = `[
+	{"type":"event","name":"Registered","anonymous":false,"inputs":[
+		{"name":"name","type":"string","indexed":true},
+		{"name":"ids","type":"uint256[]","indexed":true},
+		{"name":"owner","type":"address","indexed":true},
+		{"name":"data","type":"bytes","indexed":false},
+		{"name":"","type":"address[]","indexed":false}
+	]},
+	{"type":"event","name":"Hidden","anonymous":true,"inputs":[
+		{"name":"value","type":"uint256","indexed":true}
+	]}
+]`
+
+func TestDecodeLogDynamic(t *testing.T) {
+	a, err := ParseJSON(customABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := a.Events["Registered"]
+	data, err := e.Inputs.NonIndexed().Pack([]byte{1, 2, 3}, []common.Address{common.HexToAddress(receiverEVM)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nameHash := crypto.Keccak256Hash([]byte("alice"))
+	idsHash := crypto.Keccak256Hash(make([]byte, 64))
+	topics := [][]byte{
+		e.ID.Bytes(),
+		nameHash.Bytes(),
+		idsHash.Bytes(),
+		common.LeftPadBytes(mustDecodeHex(t, senderEVM), 32),
+	}
+
+	event, err := DecodeLog(a, mustDecodeHex(t, usdtEVM), topics, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the indexed dynamic values are only known by their hash
+	if got, ok := event.Args["name"].(common.Hash); !ok || got != nameHash {
+		t.Errorf("name = %v, want %s", event.Args["name"], nameHash)
+	}
+	if got, ok := event.Args["ids"].(common.Hash); !ok || got != idsHash {
+		t.Errorf("ids = %v, want %s", event.Args["ids"], idsHash)
+	}
+	if owner := event.Args["owner"].(address.Address); owner.Hex() != tronAddress(t, senderEVM).Hex() {
+		t.Errorf("owner = %s", owner.Hex())
+	}
+	if got := event.Args["data"].([]byte); hex.EncodeToString(got) != "010203" {
+		t.Errorf("data = %x", got)
+	}
+	if got, ok := event.Args["arg4"].([]address.Address); !ok || len(got) != 1 || got[0].Hex() != tronAddress(t, receiverEVM).Hex() {
+		t.Errorf("arg4 = %v", event.Args["arg4"])
+	}
+	if len(event.Values) != 5 {
+		t.Errorf("Values = %v", event.Values)
+	}
+}
+
+func TestDecodeLogNotFound(t *testing.T) {
+	a, err := ParseJSON(customABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// anonymous events have no signature topic to be matched with
+	hidden := a.Events["Hidden"]
+	value := common.LeftPadBytes([]byte{1}, 32)
+	for _, topics := range [][][]byte{
+		{value},
+		{hidden.ID.Bytes(), value},
+		nil,
+	} {
+		if _, err := DecodeLog(a, mustDecodeHex(t, usdtEVM), topics, nil); !errors.Is(err, ErrEventNotFound) {
+			t.Errorf("DecodeLog(%x) = %v, want %v", topics, err, ErrEventNotFound)
+		}
+	}
+
+	// unknown signature
+	unknown := trc20Transfer
+	unknown.topics = append([]string{strings.Repeat("00", 32)}, unknown.topics[1:]...)
+	if _, err := unknown.decode(t, nil); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("DecodeLog(unknown) = %v, want %v", err, ErrEventNotFound)
+	}
+}
+
+func TestDecodeLogInvalid(t *testing.T) {
+	truncated := trc20Transfer
+	truncated.data = truncated.data[:32]
+	if _, err := truncated.decode(t, nil); err == nil || errors.Is(err, ErrEventNotFound) {
+		t.Errorf("DecodeLog(truncated data) = %v", err)
+	}
+
+	shortTopic := trc20Transfer
+	shortTopic.topics = []string{transferTopic, senderEVM, trc20Transfer.topics[2]}
+	if _, err := shortTopic.decode(t, nil); err == nil || errors.Is(err, ErrEventNotFound) {
+		t.Errorf("DecodeLog(short topic) = %v", err)
+	}
+}
+
+func TestEventScan(t *testing.T) {
+	event, err := trc20Transfer.decode(t, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		from, to address.Address
+		value    *big.Int
+		flag     bool
+	)
+	for name, targets := range map[string][]interface{}{
+		"missing target": {&from, &to},
+		"extra target":   {&from, &to, &value, &flag},
+		"not a pointer":  {&from, to, &value},
+		"nil pointer":    {&from, (*address.Address)(nil), &value},
+		"wrong type":     {&from, &to, &flag},
+	} {
+		if err := event.Scan(targets...); err == nil {
+			t.Errorf("Scan(%s) accepted the targets", name)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// the entry types of the newer nodes, missing from the generated enum
+const (
+	entryTypeReceive core.SmartContract_ABI_Entry_EntryType = 5
+	entryTypeError   core.SmartContract_ABI_Entry_EntryType = 6
+)
+
+type entryJSON struct {
+	Type            string      `json:"type"`
+	Name            string      `json:"name,omitempty"`
+	Inputs          []paramJSON `json:"inputs"`
+	Outputs         []paramJSON `json:"outputs,omitempty"`
+	StateMutability string      `json:"stateMutability,omitempty"`
+	Anonymous       bool        `json:"anonymous,omitempty"`
+	Constant        bool        `json:"constant,omitempty"`
+	Payable         bool        `json:"payable,omitempty"`
+}
+
+type paramJSON struct {
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Indexed    bool        `json:"indexed,omitempty"`
+	Components []paramJSON `json:"components,omitempty"`
+}
+
+// ParseJSON parses a contract ABI in the solidity JSON format.
+// TVM specific types such as trcToken are accepted
+func ParseJSON(abiJSON string) (*abi.ABI, error) {
+	// TVM signatures keep trcToken while the values are encoded as uint256
+	var tvmSigs map[string]string
+	if strings.Contains(abiJSON, TRCTokenType) {
+		var entries []map[string]interface{}
+		if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+			return nil, err
+		}
+
+		tvmSigs = make(map[string]string)
+		for _, entry := range entries {
+			name, _ := entry["name"].(string)
+			tvmSig := name + "(" + strings.Join(canonicalTypes(entry["inputs"]), ",") + ")"
+
+			evmTypes(entry["inputs"])
+			evmTypes(entry["outputs"])
+
+			tvmSigs[name+"("+strings.Join(canonicalTypes(entry["inputs"]), ",")+")"] = tvmSig
+		}
+
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return nil, err
+		}
+		abiJSON = string(data)
+	}
+
+	a, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, m := range a.Methods {
+		if sig, ok := tvmSigs[m.Sig]; ok && sig != m.Sig {
+			m.Sig = sig
+			m.ID = crypto.Keccak256([]byte(sig))[:4]
+			a.Methods[name] = m
+		}
+	}
+	for name, e := range a.Events {
+		if sig, ok := tvmSigs[e.Sig]; ok && sig != e.Sig {
+			e.Sig = sig
+			e.ID = crypto.Keccak256Hash([]byte(sig))
+			a.Events[name] = e
+		}
+	}
+
+	return &a, nil
+}
+
+// FromSmartContractABI converts the ABI returned by GetContractABI
+func FromSmartContractABI(sc *core.SmartContract_ABI) (*abi.ABI, error) {
+	abiJSON, err := SmartContractABIToJSON(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseJSON(abiJSON)
+}
+
+// SmartContractABIToJSON converts the ABI returned by GetContractABI to the solidity JSON format
+func SmartContractABIToJSON(sc *core.SmartContract_ABI) (string, error) {
+	if sc == nil {
+		return "", fmt.Errorf("empty contract abi")
+	}
+
+	entries := make([]entryJSON, 0, len(sc.GetEntrys()))
+	for _, e := range sc.GetEntrys() {
+		entry := entryJSON{
+			Name:            e.GetName(),
+			Inputs:          toParamsJSON(e.GetInputs()),
+			Outputs:         toParamsJSON(e.GetOutputs()),
+			Anonymous:       e.GetAnonymous(),
+			Constant:        e.GetConstant(),
+			Payable:         e.GetPayable(),
+			StateMutability: strings.ToLower(e.GetStateMutability().String()),
+		}
+
+		if e.GetStateMutability() == core.SmartContract_ABI_Entry_UnknownMutabilityType {
+			entry.StateMutability = ""
+		}
+
+		switch e.GetType() {
+		case core.SmartContract_ABI_Entry_Constructor:
+			entry.Type = "constructor"
+		case core.SmartContract_ABI_Entry_Function:
+			entry.Type = "function"
+		case core.SmartContract_ABI_Entry_Event:
+			entry.Type = "event"
+		case core.SmartContract_ABI_Entry_Fallback:
+			entry.Type = "fallback"
+		case entryTypeReceive:
+			entry.Type = "receive"
+		case entryTypeError:
+			entry.Type = "error"
+		default:
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// JSONToSmartContractABI converts a contract ABI in the solidity JSON format
+// to the format of CreateSmartContract
+func JSONToSmartContractABI(abiJSON string) (*core.SmartContract_ABI, error) {
+	var entries []entryJSON
+	if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+		return nil, fmt.Errorf("invalid abi: %v", err)
+	}
+
+	sc := &core.SmartContract_ABI{}
+	for _, e := range entries {
+		entry := &core.SmartContract_ABI_Entry{
+			Name:      e.Name,
+			Inputs:    fromParamsJSON(e.Inputs),
+			Outputs:   fromParamsJSON(e.Outputs),
+			Anonymous: e.Anonymous,
+			Constant:  e.Constant,
+			Payable:   e.Payable,
+		}
+
+		switch e.Type {
+		case "constructor":
+			entry.Type = core.SmartContract_ABI_Entry_Constructor
+		case "function", "":
+			entry.Type = core.SmartContract_ABI_Entry_Function
+		case "event":
+			entry.Type = core.SmartContract_ABI_Entry_Event
+		case "fallback":
+			entry.Type = core.SmartContract_ABI_Entry_Fallback
+		case "receive":
+			entry.Type = entryTypeReceive
+		case "error":
+			entry.Type = entryTypeError
+		default:
+			return nil, fmt.Errorf("invalid abi entry type %s", e.Type)
+		}
+
+		switch e.StateMutability {
+		case "pure":
+			entry.StateMutability = core.SmartContract_ABI_Entry_Pure
+		case "view":
+			entry.StateMutability = core.SmartContract_ABI_Entry_View
+		case "nonpayable":
+			entry.StateMutability = core.SmartContract_ABI_Entry_Nonpayable
+		case "payable":
+			entry.StateMutability = core.SmartContract_ABI_Entry_Payable
+		}
+
+		sc.Entrys = append(sc.Entrys, entry)
+	}
+
+	return sc, nil
+}
+
+func fromParamsJSON(params []paramJSON) []*core.SmartContract_ABI_Entry_Param {
+	result := make([]*core.SmartContract_ABI_Entry_Param, 0, len(params))
+	for _, p := range params {
+		result = append(result, &core.SmartContract_ABI_Entry_Param{
+			Name:    p.Name,
+			Type:    paramType(p),
+			Indexed: p.Indexed,
+		})
+	}
+
+	return result
+}
+
+// paramType returns the type of p with the tuple components, "(address,uint256)[]"
+func paramType(p paramJSON) string {
+	if !strings.HasPrefix(p.Type, "tuple") {
+		return p.Type
+	}
+
+	components := make([]string, 0, len(p.Components))
+	for _, c := range p.Components {
+		components = append(components, paramType(c))
+	}
+
+	return "(" + strings.Join(components, ",") + ")" + strings.TrimPrefix(p.Type, "tuple")
+}
+
+// evmTypes replaces the TVM specific types of the JSON params, see evmType
+func evmTypes(params interface{}) {
+	list, ok := params.([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, p := range list {
+		param, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, ok := param["type"].(string); ok {
+			param["type"] = evmType(t)
+		}
+		evmTypes(param["components"])
+	}
+}
+
+// canonicalTypes returns the types of the JSON params as used in signatures
+func canonicalTypes(params interface{}) []string {
+	list, _ := params.([]interface{})
+	types := make([]string, 0, len(list))
+
+	for _, p := range list {
+		param, _ := p.(map[string]interface{})
+		t, _ := param["type"].(string)
+		if strings.HasPrefix(t, "tuple") {
+			t = "(" + strings.Join(canonicalTypes(param["components"]), ",") + ")" + strings.TrimPrefix(t, "tuple")
+		}
+		types = append(types, t)
+	}
+
+	return types
+}
+
+func toParamsJSON(params []*core.SmartContract_ABI_Entry_Param) []paramJSON {
+	result := make([]paramJSON, 0, len(params))
+	for _, p := range params {
+		param := paramJSON{
+			Name:    p.GetName(),
+			Type:    p.GetType(),
+			Indexed: p.GetIndexed(),
+		}
+
+		// tuples are stored with their components in the type, "(address,uint256)[]"
+		if m, err := parseType(p.GetType()); err == nil && len(m.Components) > 0 {
+			param.Type = m.Type
+			param.Components = toComponentsJSON(m.Components)
+		}
+
+		result = append(result, param)
+	}
+
+	return result
+}
+
+func toComponentsJSON(components []abi.ArgumentMarshaling) []paramJSON {
+	result := make([]paramJSON, 0, len(components))
+	for _, c := range components {
+		result = append(result, paramJSON{
+			Name:       c.Name,
+			Type:       c.Type,
+			Components: toComponentsJSON(c.Components),
+		})
+	}
+
+	return result
+}
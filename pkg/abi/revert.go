@@ -0,0 +1,149 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/craftto/go-tron/pkg/common"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var (
+	// errorSelector is the selector of Error(string) used by revert and require
+	errorSelector = MethodSignature("Error(string)")
+	// panicSelector is the selector of Panic(uint256) used by assert and runtime checks
+	panicSelector = MethodSignature("Panic(uint256)")
+
+	stringArgs  = abi.Arguments{{Type: mustNewType("string")}}
+	uint256Args = abi.Arguments{{Type: mustNewType("uint256")}}
+)
+
+// PanicReasons are the solidity panic codes
+var PanicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "out-of-bounds array access; popping on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// RevertError is a decoded contract revert
+type RevertError struct {
+	// Reason is the message of Error(string), the panic reason or the
+	// plain text message returned by the node
+	Reason string
+	// PanicCode is set for Panic(uint256)
+	PanicCode *big.Int
+	// CustomError is the custom error of the ABI, Args its arguments
+	CustomError *abi.Error
+	Args        []interface{}
+	// Data is the raw revert data
+	Data []byte
+}
+
+func (e *RevertError) Error() string {
+	switch {
+	case e.CustomError != nil:
+		args := make([]string, 0, len(e.Args))
+		for _, arg := range e.Args {
+			args = append(args, fmt.Sprint(arg))
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.CustomError.Name, strings.Join(args, ", "))
+	case e.PanicCode != nil:
+		return fmt.Sprintf("execution reverted: panic 0x%02x: %s", e.PanicCode, e.Reason)
+	case e.Reason != "":
+		return "execution reverted: " + e.Reason
+	case len(e.Data) > 0:
+		return "execution reverted: " + common.Bytes2Hex(e.Data)
+	}
+
+	return "execution reverted"
+}
+
+// IsRevertData reports whether data is an Error(string), Panic(uint256)
+// or custom error of a encoding
+func IsRevertData(a *abi.ABI, data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+
+	if bytes.Equal(data[:4], errorSelector) || bytes.Equal(data[:4], panicSelector) {
+		return true
+	}
+
+	return customError(a, data) != nil
+}
+
+// DecodeRevert decodes the revert data of a constant result or ResMessage,
+// with the custom errors of a when not nil.
+// Data which is not ABI encoded is kept as the plain text reason
+func DecodeRevert(a *abi.ABI, data []byte) *RevertError {
+	revert := &RevertError{Data: data}
+
+	if len(data) >= 4 {
+		switch {
+		case bytes.Equal(data[:4], errorSelector):
+			if values, err := stringArgs.Unpack(data[4:]); err == nil {
+				revert.Reason = values[0].(string)
+				return revert
+			}
+		case bytes.Equal(data[:4], panicSelector):
+			if values, err := uint256Args.Unpack(data[4:]); err == nil {
+				revert.PanicCode = values[0].(*big.Int)
+				revert.Reason = "unknown panic code"
+				if revert.PanicCode.IsUint64() {
+					if reason, ok := PanicReasons[revert.PanicCode.Uint64()]; ok {
+						revert.Reason = reason
+					}
+				}
+				return revert
+			}
+		default:
+			if e := customError(a, data); e != nil {
+				if values, err := e.Inputs.Unpack(data[4:]); err == nil {
+					revert.CustomError = e
+					revert.Args = ConvertOutputs(e.Inputs, values)
+					return revert
+				}
+			}
+		}
+	}
+
+	if utf8.Valid(data) && !bytes.ContainsRune(data, 0) {
+		revert.Reason = string(data)
+	}
+
+	return revert
+}
+
+func customError(a *abi.ABI, data []byte) *abi.Error {
+	if a == nil || len(data) < 4 {
+		return nil
+	}
+
+	for _, e := range a.Errors {
+		if bytes.Equal(e.ID[:4], data[:4]) {
+			e := e
+			return &e
+		}
+	}
+
+	return nil
+}
+
+func mustNewType(t string) abi.Type {
+	ty, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return ty
+}
@@ -0,0 +1,158 @@
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const errorsABI = `[
+	{"type":"error","name":"InsufficientBalance","inputs":[
+		{"name":"account","type":"address"},
+		{"name":"needed","type":"uint256"}
+	]},
+	{"type":"error","name":"Unauthorized","inputs":[]}
+]`
+
+func revertData(t *testing.T, sig string, args ...interface{}) []byte {
+	t.Helper()
+
+	data := MethodSignature(sig)
+	switch sig {
+	case "Error(string)":
+		packed, err := stringArgs.Pack(args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, packed...)
+	case "Panic(uint256)":
+		packed, err := uint256Args.Pack(args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, packed...)
+	}
+
+	return data
+}
+
+func TestDecodeRevertError(t *testing.T) {
+	revert := DecodeRevert(nil, revertData(t, "Error(string)", "balance too low"))
+	if revert.Reason != "balance too low" || revert.PanicCode != nil || revert.CustomError != nil {
+		t.Errorf("DecodeRevert() = %+v", revert)
+	}
+	if revert.Error() != "execution reverted: balance too low" {
+		t.Errorf("Error() = %s", revert.Error())
+	}
+}
+
+func TestDecodeRevertPanic(t *testing.T) {
+	tests := []struct {
+		code   int64
+		reason string
+	}{
+		{0x01, "assert(false)"},
+		{0x11, "arithmetic underflow or overflow"},
+		{0x12, "division or modulo by zero"},
+		{0x99, "unknown panic code"},
+	}
+
+	for _, test := range tests {
+		revert := DecodeRevert(nil, revertData(t, "Panic(uint256)", big.NewInt(test.code)))
+		if revert.PanicCode == nil || revert.PanicCode.Int64() != test.code || revert.Reason != test.reason {
+			t.Errorf("DecodeRevert(panic 0x%02x) = %+v", test.code, revert)
+		}
+		if !strings.Contains(revert.Error(), test.reason) {
+			t.Errorf("Error() = %s", revert.Error())
+		}
+	}
+}
+
+func TestDecodeRevertCustomError(t *testing.T) {
+	a, err := ParseJSON(errorsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := a.Errors["InsufficientBalance"]
+	account := common.HexToAddress(senderEVM)
+	args, err := e.Inputs.Pack(account, big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := append(e.ID[:4:4], args...)
+
+	revert := DecodeRevert(a, data)
+	if revert.CustomError == nil || revert.CustomError.Name != "InsufficientBalance" {
+		t.Fatalf("DecodeRevert() = %+v", revert)
+	}
+	if len(revert.Args) != 2 {
+		t.Fatalf("Args = %v", revert.Args)
+	}
+	if got := revert.Args[0].(address.Address); got.Hex() != tronAddress(t, senderEVM).Hex() {
+		t.Errorf("account = %s", got.Hex())
+	}
+	if got := revert.Args[1].(*big.Int); got.Int64() != 100 {
+		t.Errorf("needed = %s", got)
+	}
+	if !strings.HasPrefix(revert.Error(), "execution reverted: InsufficientBalance(") {
+		t.Errorf("Error() = %s", revert.Error())
+	}
+	if !IsRevertData(a, data) {
+		t.Error("IsRevertData() = false for a custom error")
+	}
+
+	// without the ABI, the custom error is unknown
+	if revert := DecodeRevert(nil, data); revert.CustomError != nil || revert.Reason != "" {
+		t.Errorf("DecodeRevert(nil ABI) = %+v", revert)
+	}
+	if IsRevertData(nil, data) {
+		t.Error("IsRevertData(nil ABI) = true for a custom error")
+	}
+
+	unauthorized := a.Errors["Unauthorized"]
+	if revert := DecodeRevert(a, unauthorized.ID[:4]); revert.CustomError == nil || revert.Error() != "execution reverted: Unauthorized()" {
+		t.Errorf("DecodeRevert(Unauthorized) = %+v", revert)
+	}
+}
+
+func TestDecodeRevertTruncated(t *testing.T) {
+	a, err := ParseJSON(errorsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := a.Errors["InsufficientBalance"]
+
+	for name, data := range map[string][]byte{
+		"Error(string)":       revertData(t, "Error(string)", "balance too low")[:40],
+		"Panic(uint256)":      revertData(t, "Panic(uint256)", big.NewInt(1))[:20],
+		"InsufficientBalance": append(e.ID[:4:4], make([]byte, 32)...),
+		"selector":            errorSelector[:3],
+	} {
+		revert := DecodeRevert(a, data)
+		if revert.Reason != "" || revert.PanicCode != nil || revert.CustomError != nil {
+			t.Errorf("DecodeRevert(truncated %s) = %+v", name, revert)
+		}
+		if string(revert.Data) != string(data) {
+			t.Errorf("DecodeRevert(truncated %s).Data = %x", name, revert.Data)
+		}
+	}
+}
+
+func TestDecodeRevertMessage(t *testing.T) {
+	// the nodes may return a plain text message instead of revert data
+	revert := DecodeRevert(nil, []byte("REVERT opcode executed"))
+	if revert.Reason != "REVERT opcode executed" {
+		t.Errorf("Reason = %q", revert.Reason)
+	}
+
+	var err error = DecodeRevert(nil, nil)
+	var revertErr *RevertError
+	if !errors.As(err, &revertErr) || err.Error() != "execution reverted" {
+		t.Errorf("DecodeRevert(nil) = %v", err)
+	}
+}
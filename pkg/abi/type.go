@@ -0,0 +1,151 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// NewType parses a solidity type such as "uint256", "bytes32[2][]",
+// "(address,uint256)[]" or "tuple(address to,uint256 amount)"
+func NewType(t string) (abi.Type, error) {
+	m, err := parseType(strings.TrimSpace(t))
+	if err != nil {
+		return abi.Type{}, fmt.Errorf("invalid type %s: %v", t, err)
+	}
+
+	m = evmMarshaling(m)
+	ty, err := abi.NewType(m.Type, "", m.Components)
+	if err != nil {
+		return abi.Type{}, fmt.Errorf("invalid type %s: %v", t, err)
+	}
+
+	return ty, nil
+}
+
+// TRCTokenType is the TVM type of TRC10 token ids, encoded as an uint256
+const TRCTokenType = "trcToken"
+
+// evmType replaces the TVM specific types of t by their EVM encoding
+func evmType(t string) string {
+	if strings.HasPrefix(t, TRCTokenType) {
+		return "uint256" + strings.TrimPrefix(t, TRCTokenType)
+	}
+
+	return t
+}
+
+func evmMarshaling(m abi.ArgumentMarshaling) abi.ArgumentMarshaling {
+	m.Type = evmType(m.Type)
+	if len(m.Components) > 0 {
+		components := make([]abi.ArgumentMarshaling, 0, len(m.Components))
+		for _, c := range m.Components {
+			components = append(components, evmMarshaling(c))
+		}
+		m.Components = components
+	}
+
+	return m
+}
+
+// parseType converts the tuple notation of t to the go-ethereum marshaling form
+func parseType(t string) (abi.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(t, "(") && !strings.HasPrefix(t, "tuple(") {
+		return abi.ArgumentMarshaling{Type: t}, nil
+	}
+	t = strings.TrimPrefix(t, "tuple")
+
+	end, err := closingParen(t)
+	if err != nil {
+		return abi.ArgumentMarshaling{}, err
+	}
+
+	suffix := t[end+1:]
+	if suffix != "" && !strings.HasPrefix(suffix, "[") {
+		return abi.ArgumentMarshaling{}, fmt.Errorf("unexpected %s after tuple", suffix)
+	}
+
+	parts, err := splitComponents(t[1:end])
+	if err != nil {
+		return abi.ArgumentMarshaling{}, err
+	}
+
+	components := make([]abi.ArgumentMarshaling, 0, len(parts))
+	for i, part := range parts {
+		typ, name := part, ""
+		// a component may be named, "(address to,uint256 amount)"
+		if j := strings.LastIndex(part, " "); j > 0 && !strings.HasSuffix(part, ")") && !strings.HasSuffix(part, "]") {
+			typ, name = strings.TrimSpace(part[:j]), strings.TrimSpace(part[j+1:])
+		}
+
+		c, err := parseType(typ)
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+
+		if name == "" {
+			name = fmt.Sprintf("field%d", i)
+		}
+		c.Name = name
+
+		components = append(components, c)
+	}
+
+	return abi.ArgumentMarshaling{
+		Type:       "tuple" + suffix,
+		Components: components,
+	}, nil
+}
+
+func closingParen(t string) (int, error) {
+	depth := 0
+	for i, c := range t {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unbalanced parentheses")
+}
+
+func splitComponents(s string) ([]string, error) {
+	parts := make([]string, 0)
+	depth, start := 0, 0
+
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		parts = append(parts, last)
+	}
+
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("empty tuple component")
+		}
+	}
+
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("empty tuple")
+	}
+
+	return parts, nil
+}
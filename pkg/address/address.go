@@ -2,6 +2,7 @@ package address
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"math/big"
 
 	"github.com/craftto/go-tron/pkg/common"
@@ -43,6 +44,10 @@ func (a Address) Hex() string {
 
 // Base58 get base58 encoded string
 func (a Address) Base58() string {
+	if len(a) == 0 {
+		return ""
+	}
+
 	if a[0] == 0 {
 		return new(big.Int).SetBytes(a.Bytes()).String()
 	}
@@ -50,14 +55,22 @@ func (a Address) Base58() string {
 	return common.EncodeBase58(a.Bytes())
 }
 
-// HexToAddress returns Address with byte values of s.
-// If s is larger than len(h), s will be cropped from the left.
+// IsValid reports whether a is 21 bytes long and starts with the 0x41 prefix
+func (a Address) IsValid() bool {
+	return len(a) == AddressLength && a[0] == TronBytePrefix
+}
+
+// Hex2Address returns Address with byte values of the 41 prefixed hex string s.
 func Hex2Address(s string) (Address, error) {
 	addr, err := common.Hex2Bytes(s)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := validate(addr); err != nil {
+		return nil, err
+	}
+
 	return addr, nil
 }
 
@@ -68,6 +81,10 @@ func Base58ToAddress(s string) (Address, error) {
 		return nil, err
 	}
 
+	if err := validate(addr); err != nil {
+		return nil, err
+	}
+
 	return addr, nil
 }
 
@@ -80,3 +97,15 @@ func PubkeyToAddress(p ecdsa.PublicKey) Address {
 	addressTron = append(addressTron, address.Bytes()...)
 	return addressTron
 }
+
+func validate(addr Address) error {
+	if len(addr) != AddressLength {
+		return fmt.Errorf("invalid address length %d", len(addr))
+	}
+
+	if addr[0] != TronBytePrefix {
+		return fmt.Errorf("invalid address prefix 0x%02x", addr[0])
+	}
+
+	return nil
+}
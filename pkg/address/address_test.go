@@ -0,0 +1,153 @@
+package address
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/craftto/go-tron/pkg/common"
+)
+
+const (
+	usdtBase58 = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	usdtHex    = "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+)
+
+func TestParse(t *testing.T) {
+	want := mustHex(t, usdtHex)
+
+	for _, s := range []string{
+		usdtBase58,
+		" " + usdtBase58 + "\n",
+		usdtHex,
+		strings.ToUpper(usdtHex),
+		"0x" + usdtHex,
+		"0x" + usdtHex[2:],
+		"0X" + strings.ToUpper(usdtHex[2:]),
+	} {
+		addr, err := Parse(s)
+		if err != nil {
+			t.Errorf("Parse(%q) = %v", s, err)
+			continue
+		}
+		if !bytes.Equal(addr, want) {
+			t.Errorf("Parse(%q) = %x, want %x", s, []byte(addr), want)
+		}
+		if !IsValid(s) {
+			t.Errorf("IsValid(%q) = false", s)
+		}
+	}
+
+	// base58check of a 0x42 prefixed address
+	wrongPrefix := common.EncodeBase58(append([]byte{0x42}, want[1:]...))
+
+	for _, s := range []string{
+		"",
+		"T",
+		usdtBase58[:33],
+		usdtBase58 + "1",
+		// bad checksums
+		usdtBase58[:33] + "u",
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj7t",
+		// characters outside of base58
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6O",
+		wrongPrefix,
+		// hex without its 41 prefix or with another prefix
+		usdtHex[2:],
+		"42" + usdtHex[2:],
+		"0x42" + usdtHex[2:],
+		// wrong hex lengths
+		usdtHex[:40],
+		"0x" + usdtHex[2:40],
+		usdtHex + "00",
+		"0x" + usdtHex + "00",
+		// not hex
+		"41a614f803b6fd780986a42c78ec9c7f77e6ded1zz",
+		"0xa614f803b6fd780986a42c78ec9c7f77e6ded1zz",
+	} {
+		if addr, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) = %x, want an error", s, []byte(addr))
+		}
+		if IsValid(s) {
+			t.Errorf("IsValid(%q) = true", s)
+		}
+	}
+}
+
+func TestHex2Address(t *testing.T) {
+	addr, err := Hex2Address(usdtHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.String() != usdtBase58 || addr.Hex() != "0x"+usdtHex {
+		t.Errorf("Hex2Address(%s) = %s %s", usdtHex, addr, addr.Hex())
+	}
+
+	for _, s := range []string{
+		"",
+		usdtHex[2:],
+		"42" + usdtHex[2:],
+		usdtHex + "00",
+		usdtHex[:41],
+	} {
+		if _, err := Hex2Address(s); err == nil {
+			t.Errorf("Hex2Address(%q) accepted an invalid address", s)
+		}
+	}
+}
+
+func TestBase58ToAddress(t *testing.T) {
+	addr, err := Base58ToAddress(usdtBase58)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.Hex() != "0x"+usdtHex {
+		t.Errorf("Base58ToAddress(%s) = %s", usdtBase58, addr.Hex())
+	}
+
+	zero, err := Base58ToAddress("T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zero.Hex() != "0x"+ZeroAddress {
+		t.Errorf("Base58ToAddress(zero) = %s", zero.Hex())
+	}
+
+	raw := mustHex(t, usdtHex)
+	for _, s := range []string{
+		"",
+		usdtBase58[:33] + "u",
+		common.EncodeBase58(append([]byte{0x42}, raw[1:]...)),
+		common.EncodeBase58(raw[1:]),
+		common.EncodeBase58(append(raw, 0)),
+	} {
+		if _, err := Base58ToAddress(s); err == nil {
+			t.Errorf("Base58ToAddress(%q) accepted an invalid address", s)
+		}
+	}
+}
+
+func TestAddressIsValid(t *testing.T) {
+	raw := mustHex(t, usdtHex)
+
+	tests := []struct {
+		addr Address
+		want bool
+	}{
+		{raw, true},
+		{nil, false},
+		{raw[1:], false},
+		{append(append(Address{}, raw...), 0), false},
+		{append([]byte{0x42}, raw[1:]...), false},
+	}
+
+	for _, test := range tests {
+		if got := test.addr.IsValid(); got != test.want {
+			t.Errorf("Address(%x).IsValid() = %v, want %v", []byte(test.addr), got, test.want)
+		}
+	}
+
+	if s := Address(nil).String(); s != "" {
+		t.Errorf("Address(nil).String() = %q", s)
+	}
+}
@@ -0,0 +1,63 @@
+package address
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ContractAddress returns the address of the contract deployed by the
+// CreateSmartContract transaction txID sent by owner
+func ContractAddress(txID []byte, owner Address) (Address, error) {
+	if len(txID) != HashLength {
+		return nil, fmt.Errorf("invalid txid length %d", len(txID))
+	}
+
+	if err := validate(owner); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, len(txID)+len(owner))
+	data = append(data, txID...)
+	data = append(data, owner...)
+
+	return sha3Omit12(data), nil
+}
+
+// Create2Address returns the address of a contract deployed with CREATE2 by
+// deployer. TVM hashes 0x41 ++ deployer ++ salt ++ keccak256(init code),
+// where the EVM uses the 0xff prefix
+func Create2Address(deployer Address, salt [32]byte, initCodeHash []byte) (Address, error) {
+	if len(initCodeHash) != HashLength {
+		return nil, fmt.Errorf("invalid init code hash length %d", len(initCodeHash))
+	}
+
+	if err := validate(deployer); err != nil {
+		return nil, err
+	}
+
+	return create2Address(TronBytePrefix, deployer[1:], salt, initCodeHash), nil
+}
+
+// create2Address hashes prefix ++ deployer ++ salt ++ initCodeHash, deployer is
+// the 20 bytes address shared by TVM and the EVM
+func create2Address(prefix byte, deployer []byte, salt [32]byte, initCodeHash []byte) Address {
+	data := make([]byte, 0, 1+len(deployer)+len(salt)+len(initCodeHash))
+	data = append(data, prefix)
+	data = append(data, deployer...)
+	data = append(data, salt[:]...)
+	data = append(data, initCodeHash...)
+
+	return sha3Omit12(data)
+}
+
+// sha3Omit12 keeps the last 20 bytes of the keccak256 hash of data behind the 0x41 prefix
+func sha3Omit12(data []byte) Address {
+	hash := crypto.Keccak256(data)
+
+	addr := make([]byte, 0, AddressLength)
+	addr = append(addr, TronBytePrefix)
+	addr = append(addr, hash[12:]...)
+
+	return addr
+}
@@ -0,0 +1,136 @@
+package address
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestContractAddress(t *testing.T) {
+	tests := []struct {
+		txID  string
+		owner string
+		want  string
+	}{
+		{
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb",
+			"TKPmvcPZmPHHMYqWh6H1c458mBf2ozQnx9",
+		},
+		{
+			"1b5b9ccb3e8d006a5230de9bda23ff91edc794d4f56410560830b418528e446c",
+			"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			"TTh2wKxu8iUCsUsZD1mySpPocF329XBHWg",
+		},
+	}
+
+	for _, test := range tests {
+		owner, err := Base58ToAddress(test.owner)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		addr, err := ContractAddress(mustHex(t, test.txID), owner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if addr.String() != test.want {
+			t.Errorf("ContractAddress(%s, %s) = %s, want %s", test.txID, test.owner, addr, test.want)
+		}
+	}
+
+	if _, err := ContractAddress(make([]byte, 31), Address(mustHex(t, ZeroAddress))); err == nil {
+		t.Error("accepted a 31 bytes txid")
+	}
+	if _, err := ContractAddress(make([]byte, HashLength), make([]byte, AddressLength)); err == nil {
+		t.Error("accepted an owner without the 0x41 prefix")
+	}
+}
+
+// TestCreate2Layout checks the hashed layout against the EIP-1014 vectors,
+// TVM only replaces their 0xff prefix with 0x41
+func TestCreate2Layout(t *testing.T) {
+	tests := []struct {
+		deployer string
+		salt     string
+		code     string
+		want     string
+	}{
+		{"0000000000000000000000000000000000000000", "00", "00", "4d1a2e2bb4f88f0250f26ffff098b0b30b26bf38"},
+		{"deadbeef00000000000000000000000000000000", "00", "00", "b928f69bb1d91cd65274e3c79d8986362984fda3"},
+		{"deadbeef00000000000000000000000000000000", "000000000000000000000000feed000000000000000000000000000000000000", "00", "d04116cdd17bebe565eb2422f2497e06cc1c9833"},
+		{"0000000000000000000000000000000000000000", "00", "deadbeef", "70f2b2914a2a4b783faefb75f459a580616fcb5e"},
+		{"00000000000000000000000000000000deadbeef", "cafebabe", "deadbeef", "60f3f640a8508fc6a86d45df051962668e1e8ac7"},
+		{"00000000000000000000000000000000deadbeef", "cafebabe", strings.Repeat("deadbeef", 11), "1d8bfdc5d46dc4f61d6b6115972536ebe6a8854c"},
+		{"0000000000000000000000000000000000000000", "00", "", "e33c0c7f7df4809055c3eba6c09cfe4baf1bd9e0"},
+	}
+
+	for _, test := range tests {
+		var salt [32]byte
+		saltBytes := mustHex(t, test.salt)
+		copy(salt[32-len(saltBytes):], saltBytes)
+
+		addr := create2Address(0xff, mustHex(t, test.deployer), salt, crypto.Keccak256(mustHex(t, test.code)))
+		if got := hex.EncodeToString(addr[1:]); got != test.want {
+			t.Errorf("create2Address(0xff, %s, %s, %s) = %s, want %s", test.deployer, test.salt, test.code, got, test.want)
+		}
+	}
+}
+
+func TestCreate2Address(t *testing.T) {
+	tests := []struct {
+		deployer string
+		salt     byte
+		code     string
+		want     string
+	}{
+		{"T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb", 0, "00", "TKEerhofQTEicnAHg9HWdsMzm1mA6dBDwM"},
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", 1, "", "TYjDQRHTjx6W9ogcAzcQ1qRtbSfJV7mwhh"},
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", 0, "deadbeef", "TT9ihPfvzqxfGW1aNmoojQgKpdbrKQBepG"},
+	}
+
+	for _, test := range tests {
+		deployer, err := Base58ToAddress(test.deployer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var salt [32]byte
+		salt[31] = test.salt
+
+		addr, err := Create2Address(deployer, salt, crypto.Keccak256(mustHex(t, test.code)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if addr.String() != test.want {
+			t.Errorf("Create2Address(%s, %d, %s) = %s, want %s", test.deployer, test.salt, test.code, addr, test.want)
+		}
+	}
+
+	// the zero deployer with the first EIP-1014 vector input differs by the prefix only
+	var salt [32]byte
+	addr, err := Create2Address(Address(mustHex(t, ZeroAddress)), salt, crypto.Keccak256([]byte{0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(addr[1:]) == "4d1a2e2bb4f88f0250f26ffff098b0b30b26bf38" {
+		t.Error("Create2Address used the EVM 0xff prefix")
+	}
+
+	if _, err := Create2Address(Address(mustHex(t, ZeroAddress)), salt, make([]byte, 31)); err == nil {
+		t.Error("accepted a 31 bytes init code hash")
+	}
+}
@@ -0,0 +1,99 @@
+package address
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalText implements encoding.TextMarshaler with the base58 form
+func (a Address) MarshalText() ([]byte, error) {
+	if len(a) == 0 {
+		return []byte{}, nil
+	}
+
+	if err := validate(a); err != nil {
+		return nil, err
+	}
+
+	return []byte(a.Base58()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with any form accepted by Parse
+func (a *Address) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*a = nil
+		return nil
+	}
+
+	addr, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = addr
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, an empty address is null
+func (a Address) MarshalJSON() ([]byte, error) {
+	if len(a) == 0 {
+		return []byte("null"), nil
+	}
+
+	text, err := a.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *Address) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*a = nil
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid address: %v", err)
+	}
+
+	return a.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner from a string in any form accepted by Parse
+// or from the 21 raw bytes
+func (a *Address) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		return a.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) == AddressLength && v[0] == TronBytePrefix {
+			*a = append(Address{}, v...)
+			return nil
+		}
+		return a.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("cannot scan %T into address", src)
+}
+
+// Value implements driver.Valuer with the base58 form, an empty address is NULL
+func (a Address) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+
+	if err := validate(a); err != nil {
+		return nil, err
+	}
+
+	return a.Base58(), nil
+}
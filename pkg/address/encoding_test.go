@@ -0,0 +1,140 @@
+package address
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestAddressJSON(t *testing.T) {
+	type account struct {
+		Owner Address  `json:"owner"`
+		Empty Address  `json:"empty"`
+		Ptr   *Address `json:"ptr"`
+	}
+
+	owner := Address(mustHex(t, usdtHex))
+	data, err := json.Marshal(account{Owner: owner, Ptr: &owner})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"owner":"` + usdtBase58 + `","empty":null,"ptr":"` + usdtBase58 + `"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var got account
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Owner, owner) || got.Empty != nil || got.Ptr == nil || !bytes.Equal(*got.Ptr, owner) {
+		t.Errorf("json.Unmarshal(%s) = %+v", data, got)
+	}
+
+	// every form accepted by Parse is decoded
+	for _, s := range []string{usdtHex, "0x" + usdtHex[2:]} {
+		var addr Address
+		if err := json.Unmarshal([]byte(`"`+s+`"`), &addr); err != nil {
+			t.Errorf("json.Unmarshal(%s) = %v", s, err)
+		} else if !bytes.Equal(addr, owner) {
+			t.Errorf("json.Unmarshal(%s) = %x", s, []byte(addr))
+		}
+	}
+
+	for _, invalid := range []string{`"` + usdtBase58[:33] + `u"`, `"42` + usdtHex[2:] + `"`, `1`, `{}`} {
+		var addr Address
+		if err := json.Unmarshal([]byte(invalid), &addr); err == nil {
+			t.Errorf("json.Unmarshal(%s) accepted an invalid address", invalid)
+		}
+	}
+
+	if _, err := json.Marshal(owner[1:]); err == nil {
+		t.Error("json.Marshal() accepted a 20 bytes address")
+	}
+}
+
+func TestAddressText(t *testing.T) {
+	owner := Address(mustHex(t, usdtHex))
+
+	text, err := owner.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != usdtBase58 {
+		t.Errorf("MarshalText() = %s, want %s", text, usdtBase58)
+	}
+
+	var addr Address
+	if err := addr.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(addr, owner) {
+		t.Errorf("UnmarshalText(%s) = %x", text, []byte(addr))
+	}
+
+	if err := addr.UnmarshalText(nil); err != nil || addr != nil {
+		t.Errorf("UnmarshalText(nil) = %x, %v", []byte(addr), err)
+	}
+
+	if _, err := Address(append([]byte{0x42}, owner[1:]...)).MarshalText(); err == nil {
+		t.Error("MarshalText() accepted a 0x42 prefix")
+	}
+}
+
+func TestAddressSQL(t *testing.T) {
+	owner := Address(mustHex(t, usdtHex))
+
+	value, err := owner.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != usdtBase58 {
+		t.Errorf("Value() = %v, want %s", value, usdtBase58)
+	}
+
+	if value, err := Address(nil).Value(); value != nil || err != nil {
+		t.Errorf("Address(nil).Value() = %v, %v", value, err)
+	}
+	if _, err := owner[1:].Value(); err == nil {
+		t.Error("Value() accepted a 20 bytes address")
+	}
+
+	for _, src := range []interface{}{
+		value,
+		usdtHex,
+		[]byte(usdtBase58),
+		[]byte(owner),
+	} {
+		var addr Address
+		if err := addr.Scan(src); err != nil {
+			t.Errorf("Scan(%v) = %v", src, err)
+			continue
+		}
+		if !bytes.Equal(addr, owner) {
+			t.Errorf("Scan(%v) = %x", src, []byte(addr))
+		}
+	}
+
+	// the scanned raw bytes are copied
+	raw := []byte(owner)
+	var addr Address
+	if err := addr.Scan(raw); err != nil {
+		t.Fatal(err)
+	}
+	raw[1] ^= 0xff
+	if !bytes.Equal(addr, mustHex(t, usdtHex)) {
+		t.Error("Scan() kept a reference to the source bytes")
+	}
+
+	if err := addr.Scan(nil); err != nil || addr != nil {
+		t.Errorf("Scan(nil) = %x, %v", []byte(addr), err)
+	}
+
+	for _, src := range []interface{}{42, usdtBase58[:33] + "u", []byte(owner[1:])} {
+		var addr Address
+		if err := addr.Scan(src); err == nil {
+			t.Errorf("Scan(%v) accepted an invalid address", src)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package address
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/craftto/go-tron/pkg/common"
+)
+
+// Parse returns the Address of s, which may be a base58 address,
+// a 41 prefixed hex address or a 0x prefixed 20 bytes EVM hex address
+func Parse(s string) (Address, error) {
+	s = strings.TrimSpace(s)
+
+	switch {
+	case len(s) == AddressLengthBase58:
+		return Base58ToAddress(s)
+	case common.Has0xPrefix(s) && len(s) == 2+2*(AddressLength-1):
+		return EVMToAddress(s)
+	case common.Has0xPrefix(s) && len(s) == 2+2*AddressLength,
+		len(s) == 2*AddressLength:
+		return Hex2Address(s)
+	}
+
+	return nil, fmt.Errorf("invalid address %s", s)
+}
+
+// EVMToAddress returns the Address of a 20 bytes EVM hex address
+func EVMToAddress(s string) (Address, error) {
+	evm, err := common.Hex2Bytes(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(evm) != AddressLength-1 {
+		return nil, fmt.Errorf("invalid evm address length %d", len(evm))
+	}
+
+	return EVMBytesToAddress(evm), nil
+}
+
+// EVMBytesToAddress adds the 0x41 prefix to a 20 bytes EVM address such as
+// the addresses of the logs, other addresses are returned as is
+func EVMBytesToAddress(addr []byte) Address {
+	if len(addr) == AddressLength-1 {
+		return append([]byte{TronBytePrefix}, addr...)
+	}
+
+	return addr
+}
+
+// IsValid reports whether s is an address accepted by Parse
+func IsValid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/craftto/go-tron/pkg/transaction"
+)
+
+type callerKey struct{}
+
+// Record is one signature in the audit log. Hash covers every other field,
+// including PrevHash, so the records form a chain
+type Record struct {
+	Sequence  uint64            `json:"sequence"`
+	Timestamp time.Time         `json:"timestamp"`
+	TxID      string            `json:"txid"`
+	Signer    string            `json:"signer"`
+	Contract  json.RawMessage   `json:"contract"`
+	Caller    map[string]string `json:"caller,omitempty"`
+	PrevHash  string            `json:"prevHash"`
+	Hash      string            `json:"hash"`
+}
+
+// ComputeHash returns the hash of the record with its Hash field ignored
+func (r *Record) ComputeHash() (string, error) {
+	c := *r
+	c.Hash = ""
+
+	data, err := json.Marshal(&c)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// WithCaller returns a copy of ctx carrying the caller detail key=value,
+// which is stored in the audit record of the next signature made with it
+func WithCaller(ctx context.Context, key, value string) context.Context {
+	caller := make(map[string]string)
+	for k, v := range Caller(ctx) {
+		caller[k] = v
+	}
+	caller[key] = value
+
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// Caller returns the caller details attached to ctx
+func Caller(ctx context.Context) map[string]string {
+	caller, _ := ctx.Value(callerKey{}).(map[string]string)
+	return caller
+}
+
+// Log appends hash chained records to a Sink.
+// It implements keystore.Auditor
+type Log struct {
+	mu   sync.Mutex
+	sink Sink
+	last *Record
+
+	// Now returns the timestamp of new records
+	Now func() time.Time
+}
+
+// New returns a Log continuing the chain already stored in sink
+func New(sink Sink) (*Log, error) {
+	last, err := sink.Last()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Log{
+		sink: sink,
+		last: last,
+		Now:  time.Now,
+	}, nil
+}
+
+// Record appends the signature of tx by signer to the log
+func (l *Log) Record(ctx context.Context, signer address.Address, tx *core.Transaction) error {
+	txID, err := transaction.RawDataHash(tx)
+	if err != nil {
+		return err
+	}
+
+	summary, err := transaction.DecodeContract(tx)
+	if err != nil {
+		return err
+	}
+
+	contract, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r := &Record{
+		Timestamp: l.Now().UTC(),
+		TxID:      hex.EncodeToString(txID),
+		Signer:    signer.String(),
+		Contract:  contract,
+		Caller:    Caller(ctx),
+	}
+
+	if l.last != nil {
+		r.Sequence = l.last.Sequence + 1
+		r.PrevHash = l.last.Hash
+	}
+
+	if r.Hash, err = r.ComputeHash(); err != nil {
+		return err
+	}
+
+	if err := l.sink.Append(r); err != nil {
+		return err
+	}
+
+	l.last = r
+
+	return nil
+}
+
+// Verify walks the whole chain stored in the sink
+func (l *Log) Verify() error {
+	records, err := l.sink.Records()
+	if err != nil {
+		return err
+	}
+
+	return Verify(records)
+}
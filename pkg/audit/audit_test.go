@@ -0,0 +1,217 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/policy"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+const testPrivateKey = "b5a4cea271ff424d7c31dc12a3e43e401df7a40d7412a15750f3f0b6b5449a28"
+
+func newTransfer(t *testing.T, amount int64) *core.Transaction {
+	t.Helper()
+
+	to := make([]byte, 21)
+	to[0] = 0x41
+	value, err := proto.Marshal(&core.TransferContract{ToAddress: to, Amount: amount})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &core.Transaction{RawData: &core.TransactionRaw{
+		Contract: []*core.Transaction_Contract{{
+			Type:      core.Transaction_Contract_TransferContract,
+			Parameter: &any.Any{Value: value},
+		}},
+		Timestamp: amount,
+	}}
+}
+
+// newChain signs n transfers with a keystore audited by a log stored in a file
+func newChain(t *testing.T, n int) (*Log, *FileSink) {
+	t.Helper()
+
+	sink, err := NewFileSink(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := New(sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Now = func() time.Time { return time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	ks, err := keystore.ImportFromPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks.SetAuditor(log)
+
+	for i := 0; i < n; i++ {
+		if _, err := ks.SignTx(newTransfer(t, int64(i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return log, sink
+}
+
+func TestLogChain(t *testing.T) {
+	log, sink := newChain(t, 3)
+	if err := log.Verify(); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+
+	records, err := sink.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+	for i, r := range records {
+		if r.Sequence != uint64(i) {
+			t.Errorf("records[%d].Sequence = %d", i, r.Sequence)
+		}
+		if i > 0 && r.PrevHash != records[i-1].Hash {
+			t.Errorf("records[%d].PrevHash = %s, want %s", i, r.PrevHash, records[i-1].Hash)
+		}
+	}
+
+	// a new log continues the chain stored in the sink
+	reopened, err := New(sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks, err := keystore.ImportFromPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks.SetAuditor(reopened)
+	if _, err := ks.SignTx(newTransfer(t, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Verify(); err != nil {
+		t.Fatalf("Verify() after reopening = %v", err)
+	}
+}
+
+func TestVerifyAltered(t *testing.T) {
+	tests := []struct {
+		name     string
+		alter    func(records []*Record) []*Record
+		sequence uint64
+	}{
+		{
+			name: "tampered record",
+			alter: func(records []*Record) []*Record {
+				records[1].TxID = records[0].TxID
+				return records
+			},
+			sequence: 1,
+		},
+		{
+			name: "tampered record with its hash recomputed",
+			alter: func(records []*Record) []*Record {
+				records[1].Timestamp = records[1].Timestamp.Add(time.Hour)
+				records[1].Hash, _ = records[1].ComputeHash()
+				return records
+			},
+			sequence: 2,
+		},
+		{
+			name: "removed record",
+			alter: func(records []*Record) []*Record {
+				return append(records[:1], records[2:]...)
+			},
+			sequence: 2,
+		},
+		{
+			name: "removed and renumbered record",
+			alter: func(records []*Record) []*Record {
+				records = append(records[:1], records[2:]...)
+				records[1].Sequence = 1
+				records[1].Hash, _ = records[1].ComputeHash()
+				return records
+			},
+			sequence: 1,
+		},
+		{
+			name: "reordered records",
+			alter: func(records []*Record) []*Record {
+				records[1], records[2] = records[2], records[1]
+				return records
+			},
+			sequence: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, sink := newChain(t, 4)
+			records, err := sink.Records()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var chainErr *ChainError
+			if err := Verify(test.alter(records)); !errors.As(err, &chainErr) {
+				t.Fatalf("Verify() = %v, want a *ChainError", err)
+			}
+			if chainErr.Sequence != test.sequence {
+				t.Errorf("Verify() failed at record %d, want %d: %v", chainErr.Sequence, test.sequence, chainErr)
+			}
+		})
+	}
+}
+
+func TestRecordCaller(t *testing.T) {
+	sink, err := NewFileSink(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := New(sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks, err := keystore.ImportFromPrivateKey(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks.SetAuditor(log)
+
+	p, err := policy.New(&policy.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the caller context goes through the policy signer down to the keystore auditor
+	ctx := WithCaller(WithCaller(context.Background(), "user", "ops"), "request", "42")
+	if _, err := policy.NewSigner(ks, p).SignTxWithContext(ctx, newTransfer(t, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := sink.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	if caller := records[0].Caller; caller["user"] != "ops" || caller["request"] != "42" {
+		t.Errorf("Caller = %v", caller)
+	}
+	if records[0].Signer != ks.Address.String() {
+		t.Errorf("Signer = %s, want %s", records[0].Signer, ks.Address)
+	}
+}
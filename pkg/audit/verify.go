@@ -0,0 +1,45 @@
+package audit
+
+import "fmt"
+
+// ChainError reports the first record breaking the chain
+type ChainError struct {
+	Sequence uint64
+	Reason   string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("audit record %d: %s", e.Sequence, e.Reason)
+}
+
+// Verify checks that records are consecutive, that every hash matches its
+// record and that every record links to the hash of the previous one
+func Verify(records []*Record) error {
+	var prev *Record
+
+	for i, r := range records {
+		if r.Sequence != uint64(i) {
+			return &ChainError{Sequence: r.Sequence, Reason: fmt.Sprintf("expected sequence %d", i)}
+		}
+
+		hash, err := r.ComputeHash()
+		if err != nil {
+			return &ChainError{Sequence: r.Sequence, Reason: err.Error()}
+		}
+		if hash != r.Hash {
+			return &ChainError{Sequence: r.Sequence, Reason: "hash mismatch, record was altered"}
+		}
+
+		prevHash := ""
+		if prev != nil {
+			prevHash = prev.Hash
+		}
+		if r.PrevHash != prevHash {
+			return &ChainError{Sequence: r.Sequence, Reason: "previous hash mismatch, chain was altered"}
+		}
+
+		prev = r
+	}
+
+	return nil
+}
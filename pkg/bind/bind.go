@@ -0,0 +1,285 @@
+package bind
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+type tmplData struct {
+	Package string
+	Type    string
+	ABI     string
+	Calls   []*tmplMethod
+	Txs     []*tmplMethod
+	Events  []*tmplEvent
+}
+
+type tmplArg struct {
+	Name   string
+	GoType string
+}
+
+type tmplMethod struct {
+	Name    string
+	Sig     string
+	Payable bool
+	Inputs  []tmplArg
+	Outputs []tmplArg
+}
+
+type tmplEvent struct {
+	Name   string
+	Sig    string
+	Fields []tmplArg
+}
+
+// Generate returns the Go source of a package pkg binding the contract
+// described by abiJSON as the type typeName
+func Generate(abiJSON, pkg, typeName string) ([]byte, error) {
+	a, err := abi.ParseJSON(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &tmplData{
+		Package: pkg,
+		Type:    capitalise(typeName),
+		ABI:     strings.ReplaceAll(abiJSON, "`", ""),
+	}
+
+	// the event types are prefixed by the binding type, next to its ABI constant
+	eventNames := map[string]bool{"ABI": true}
+	// the methods of the binding, next to the embedded contract
+	methodNames := map[string]bool{"Contract": true}
+
+	names := make([]string, 0, len(a.Events))
+	for name := range a.Events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		e := a.Events[name]
+		if e.Anonymous {
+			continue
+		}
+
+		event := &tmplEvent{
+			Name: uniqueName(capitalise(e.Name), eventNames),
+			Sig:  e.Sig,
+		}
+		methodNames["Parse"+event.Name] = true
+		methodNames["Filter"+event.Name] = true
+
+		// Raw is the decoded event
+		fields := map[string]bool{"Raw": true}
+		for i, input := range e.Inputs {
+			field := tmplArg{
+				Name:   capitalise(argName(input.Name, "arg", i)),
+				GoType: goType(input.Type),
+			}
+			if fields[field.Name] {
+				field.Name = fmt.Sprintf("%s%d", field.Name, i)
+			}
+			fields[field.Name] = true
+
+			if input.Indexed && isHashedTopic(input.Type) {
+				field.GoType = "ethcommon.Hash"
+			}
+			event.Fields = append(event.Fields, field)
+		}
+		data.Events = append(data.Events, event)
+	}
+
+	names = names[:0]
+	for name := range a.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		m := a.Methods[name]
+		method := &tmplMethod{
+			Name:    uniqueName(capitalise(m.Name), methodNames),
+			Sig:     m.Sig,
+			Payable: m.IsPayable(),
+			Inputs:  bindArgs(m.Inputs, "arg"),
+			Outputs: bindArgs(m.Outputs, "ret"),
+		}
+
+		if m.IsConstant() {
+			data.Calls = append(data.Calls, method)
+		} else {
+			data.Txs = append(data.Txs, method)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := bindTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %v", err)
+	}
+
+	return code, nil
+}
+
+func bindArgs(args ethabi.Arguments, prefix string) []tmplArg {
+	result := make([]tmplArg, 0, len(args))
+	used := make(map[string]bool)
+
+	for i, arg := range args {
+		name := argName(arg.Name, prefix, i)
+		if used[name] {
+			name = fmt.Sprintf("%s%d", name, i)
+		}
+		used[name] = true
+
+		result = append(result, tmplArg{
+			Name:   name,
+			GoType: goType(arg.Type),
+		})
+	}
+
+	return result
+}
+
+// reserved are the identifiers of the generated methods: their parameters,
+// local variables and imported packages
+var reserved = map[string]bool{
+	"ks": true, "callValue": true, "out": true, "err": true, "ok": true,
+	"bytes": true, "errors": true, "fmt": true, "big": true, "abi": true, "address": true,
+	"client": true, "contract": true, "keystore": true, "transaction": true, "ethcommon": true,
+}
+
+// argName returns a Go identifier for an ABI argument name which does not
+// shadow the identifiers of the generated code
+func argName(name, prefix string, i int) string {
+	name = strings.TrimLeft(name, "_")
+	if name == "" {
+		return fmt.Sprintf("%s%d", prefix, i)
+	}
+
+	runes := []rune(ethabi.ToCamelCase(name))
+	runes[0] = unicode.ToLower(runes[0])
+	name = string(runes)
+
+	if token.IsKeyword(name) || reserved[name] || isOutputVar(name) || types.Universe.Lookup(name) != nil {
+		return name + "_"
+	}
+
+	return name
+}
+
+// isOutputVar reports whether name is one of the ret0, ret1... outputs of the calls
+func isOutputVar(name string) bool {
+	index := strings.TrimPrefix(name, "ret")
+	if index == "" || index == name {
+		return false
+	}
+
+	for _, r := range index {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// uniqueName returns name, suffixed until it is not in used, and adds it to used
+func uniqueName(name string, used map[string]bool) string {
+	for used[name] {
+		name += "0"
+	}
+	used[name] = true
+
+	return name
+}
+
+func capitalise(s string) string {
+	s = ethabi.ToCamelCase(strings.TrimLeft(s, "_"))
+	if s == "" {
+		return s
+	}
+
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// goType returns the Go type of the values exchanged with contract.Contract for ty
+func goType(ty ethabi.Type) string {
+	switch ty.T {
+	case ethabi.AddressTy:
+		return "address.Address"
+	case ethabi.IntTy, ethabi.UintTy:
+		prefix := "int"
+		if ty.T == ethabi.UintTy {
+			prefix = "uint"
+		}
+		switch ty.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, ty.Size)
+		}
+		return "*big.Int"
+	case ethabi.BoolTy:
+		return "bool"
+	case ethabi.StringTy:
+		return "string"
+	case ethabi.BytesTy:
+		return "[]byte"
+	case ethabi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", ty.Size)
+	case ethabi.SliceTy, ethabi.ArrayTy:
+		elem := goType(*ty.Elem)
+		if elem == "interface{}" {
+			return elem
+		}
+		if ty.T == ethabi.SliceTy {
+			return "[]" + elem
+		}
+		return fmt.Sprintf("[%d]%s", ty.Size, elem)
+	}
+
+	return "interface{}"
+}
+
+func isHashedTopic(ty ethabi.Type) bool {
+	switch ty.T {
+	case ethabi.StringTy, ethabi.BytesTy, ethabi.SliceTy, ethabi.ArrayTy, ethabi.TupleTy:
+		return true
+	}
+	return false
+}
+
+func zero(goType string) string {
+	switch {
+	case goType == "bool":
+		return "false"
+	case goType == "string":
+		return `""`
+	case strings.HasPrefix(goType, "int"), strings.HasPrefix(goType, "uint"):
+		return "0"
+	case strings.HasPrefix(goType, "[") && !strings.HasPrefix(goType, "[]"):
+		return goType + "{}"
+	}
+	return "nil"
+}
+
+var bindTemplate = template.Must(template.New("bind").Funcs(template.FuncMap{
+	"zero": zero,
+}).Parse(tmplSource))
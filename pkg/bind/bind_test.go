@@ -0,0 +1,152 @@
+package bind
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/craftto/go-tron/pkg/abi"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// reservedABI names its arguments, events and methods after the identifiers
+// of the generated code
+const reservedABI = `[
+	{"type":"function","name":"lookup","stateMutability":"view","inputs":[
+		{"name":"type","type":"uint256"},
+		{"name":"address","type":"address"},
+		{"name":"fmt","type":"string"},
+		{"name":"ret0","type":"bool"},
+		{"name":"ok","type":"bool"},
+		{"name":"string","type":"bytes"},
+		{"name":"_out","type":"uint8"}
+	],"outputs":[
+		{"name":"","type":"address"},
+		{"name":"err","type":"string"}
+	]},
+	{"type":"function","name":"Lookup","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"deposit","stateMutability":"payable","inputs":[
+		{"name":"ks","type":"address"},
+		{"name":"callValue","type":"uint256"},
+		{"name":"len","type":"uint256"}
+	],"outputs":[]},
+	{"type":"function","name":"contract","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"parseRaw","stateMutability":"nonpayable","inputs":[],"outputs":[]},
+	{"type":"event","name":"Raw","anonymous":false,"inputs":[
+		{"name":"raw","type":"bytes","indexed":false},
+		{"name":"Raw","type":"uint256","indexed":false},
+		{"name":"func","type":"address","indexed":true}
+	]},
+	{"type":"event","name":"ABI","anonymous":false,"inputs":[]}
+]`
+
+var bindTests = []struct {
+	name string
+	abi  string
+}{
+	{"trc20", abi.TRC20ABI},
+	{"trc721", abi.TRC721ABI},
+	{"trc1155", abi.TRC1155ABI},
+	{"reserved", reservedABI},
+}
+
+func TestGenerateGolden(t *testing.T) {
+	for _, test := range bindTests {
+		t.Run(test.name, func(t *testing.T) {
+			code, err := Generate(test.abi, test.name, test.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", test.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, code, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(code, want) {
+				t.Errorf("Generate() differs from %s, run go test -update to review the changes", golden)
+			}
+		})
+	}
+}
+
+// TestGenerateBuild builds the generated packages, the shadowed identifiers
+// only fail the type checks
+func TestGenerateBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the generated code")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+
+	// inside the module for the imports, ignored by ./... with the _ prefix
+	dir, err := os.MkdirTemp(".", "_build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pkgs := make([]string, 0, len(bindTests))
+	for _, test := range bindTests {
+		code, err := Generate(test.abi, test.name, test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pkgDir := filepath.Join(dir, test.name)
+		if err := os.Mkdir(pkgDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pkgDir, test.name+".go"), code, 0644); err != nil {
+			t.Fatal(err)
+		}
+		pkgs = append(pkgs, "./"+filepath.ToSlash(pkgDir))
+	}
+
+	cmd := exec.Command("go", append([]string{"vet"}, pkgs...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go vet %s: %v\n%s", strings.Join(pkgs, " "), err, out)
+	}
+}
+
+func TestArgName(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"", "arg3"},
+		{"_owner", "owner"},
+		{"token_id", "tokenId"},
+		{"Spender", "spender"},
+		{"type", "type_"},
+		{"func", "func_"},
+		{"ks", "ks_"},
+		{"_err", "err_"},
+		{"ok", "ok_"},
+		{"address", "address_"},
+		{"ethcommon", "ethcommon_"},
+		{"string", "string_"},
+		{"len", "len_"},
+		{"nil", "nil_"},
+		{"ret1", "ret1_"},
+		{"ret", "ret"},
+		{"retry", "retry"},
+	}
+
+	for _, test := range tests {
+		if got := argName(test.name, "arg", 3); got != test.want {
+			t.Errorf("argName(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package bind
+
+const tmplSource = `// Code generated by tronabigen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/contract"
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/transaction"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = bytes.Equal
+	_ = errors.Is
+	_ = fmt.Errorf
+	_ = big.NewInt
+	_ = abi.ParseJSON
+	_ = address.Parse
+	_ = keystore.ImportFromPrivateKey
+	_ = transaction.GetTransactionReceipt
+	_ = ethcommon.Hash{}
+)
+
+// {{.Type}}ABI is the input ABI used to generate the binding from.
+const {{.Type}}ABI = ` + "`{{.ABI}}`" + `
+
+// {{.Type}} is a binding of the {{.Type}} contract
+type {{.Type}} struct {
+	*contract.Contract
+}
+
+// New{{.Type}} returns the {{.Type}} contract deployed at contractAddr
+func New{{.Type}}(g *client.GrpcClient, contractAddr string) (*{{.Type}}, error) {
+	c, err := contract.New(g, contractAddr, {{.Type}}ABI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{.Type}}{Contract: c}, nil
+}
+{{range .Calls}}
+// {{.Name}} calls the constant method {{.Sig}}
+func (_c *{{$.Type}}) {{.Name}}({{range $i, $a := .Inputs}}{{if $i}}, {{end}}{{$a.Name}} {{$a.GoType}}{{end}}) ({{range .Outputs}}{{.GoType}}, {{end}}error) {
+	out, err := _c.Contract.Call("{{.Sig}}"{{range .Inputs}}, {{.Name}}{{end}})
+	if err != nil {
+		return {{range .Outputs}}{{zero .GoType}}, {{end}}err
+	}
+	if len(out) != {{len .Outputs}} {
+		return {{range .Outputs}}{{zero .GoType}}, {{end}}fmt.Errorf("{{.Sig}}: expected {{len .Outputs}} outputs, got %d", len(out))
+	}
+{{$outputs := .Outputs}}{{range $i, $o := .Outputs}}{{if eq $o.GoType "interface{}"}}
+	ret{{$i}} := out[{{$i}}]
+{{else}}
+	ret{{$i}}, ok := out[{{$i}}].({{$o.GoType}})
+	if !ok {
+		return {{range $outputs}}{{zero .GoType}}, {{end}}fmt.Errorf("{{$.Type}}: unexpected output {{$i}} type %T", out[{{$i}}])
+	}
+{{end}}{{end}}
+	return {{range $i, $o := .Outputs}}ret{{$i}}, {{end}}nil
+}
+{{end}}{{range .Txs}}
+// {{.Name}} signs and broadcasts a call of {{.Sig}}
+func (_c *{{$.Type}}) {{.Name}}(ks keystore.Signer{{if .Payable}}, callValue int64{{end}}{{range .Inputs}}, {{.Name}} {{.GoType}}{{end}}) (*transaction.Transaction, error) {
+	return _c.Contract.TransactWithValue(ks, {{if .Payable}}callValue{{else}}0{{end}}, nil, "{{.Sig}}"{{range .Inputs}}, {{.Name}}{{end}})
+}
+{{end}}{{range .Events}}
+// {{$.Type}}{{.Name}} is the event {{.Sig}}
+type {{$.Type}}{{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.GoType}}
+{{end}}	Raw *abi.Event
+}
+
+// Parse{{.Name}} decodes a log of the event {{.Sig}}
+func (_c *{{$.Type}}) Parse{{.Name}}(log transaction.Log) (*{{$.Type}}{{.Name}}, error) {
+	event, err := log.Decode(_c.Contract.ABI)
+	if err != nil {
+		return nil, err
+	}
+	if event.Signature != "{{.Sig}}" {
+		return nil, abi.ErrEventNotFound
+	}
+	if len(event.Values) != {{len .Fields}} {
+		return nil, fmt.Errorf("{{.Sig}}: expected {{len .Fields}} values, got %d", len(event.Values))
+	}
+
+	result := &{{$.Type}}{{.Name}}{Raw: event}
+	var ok bool
+{{range $i, $f := .Fields}}{{if eq $f.GoType "interface{}"}}	result.{{$f.Name}} = event.Values[{{$i}}]
+{{else}}	if result.{{$f.Name}}, ok = event.Values[{{$i}}].({{$f.GoType}}); !ok {
+		return nil, fmt.Errorf("{{$.Type}}: unexpected {{$f.Name}} type %T", event.Values[{{$i}}])
+	}
+{{end}}{{end}}	_ = ok
+
+	return result, nil
+}
+
+// Filter{{.Name}} returns the {{.Sig}} events emitted by the contract in receipt
+func (_c *{{$.Type}}) Filter{{.Name}}(receipt *transaction.TransactionReceipt) ([]*{{$.Type}}{{.Name}}, error) {
+	events := make([]*{{$.Type}}{{.Name}}, 0)
+	for _, log := range receipt.Logs {
+		if !bytes.Equal(log.Address, _c.Contract.Address) {
+			continue
+		}
+
+		event, err := _c.Parse{{.Name}}(log)
+		if errors.Is(err, abi.ErrEventNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+{{end}}`
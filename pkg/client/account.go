@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -13,6 +14,9 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrAccountNotFound is returned for the addresses not activated on chain
+var ErrAccountNotFound = errors.New("account not found")
+
 // GetAccount from BASE58 address
 func (g *GrpcClient) GetAccount(addr string) (*core.Account, error) {
 	account := new(core.Account)
@@ -32,7 +36,7 @@ func (g *GrpcClient) GetAccount(addr string) (*core.Account, error) {
 	}
 
 	if !bytes.Equal(acc.Address, account.Address) {
-		return nil, fmt.Errorf("account not found")
+		return nil, ErrAccountNotFound
 	}
 
 	return acc, nil
@@ -8,8 +8,10 @@ import (
 	"github.com/craftto/go-tron/pkg/address"
 	"github.com/craftto/go-tron/pkg/common"
 	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/proto/api"
 	"github.com/craftto/go-tron/pkg/proto/core"
 	"github.com/craftto/go-tron/pkg/transaction"
+	"google.golang.org/protobuf/proto"
 )
 
 type TokenAmount struct {
@@ -41,10 +43,7 @@ func (g *GrpcClient) TriggerConstantContract(contractAddress, from, method strin
 		Data:            data,
 	}
 
-	ctx, cancel := g.GetContext()
-	defer cancel()
-
-	result, err := g.Client.TriggerConstantContract(ctx, ct)
+	result, err := g.TriggerConstantSmartContract(ct)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +55,78 @@ func (g *GrpcClient) TriggerConstantContract(contractAddress, from, method strin
 	}, nil
 }
 
-func (g *GrpcClient) TriggerContract(ks *keystore.Keystore, contractAddress, method string, paramData []byte, feeLimit, amount int64, tokenAmount *TokenAmount) (*transaction.Transaction, error) {
+// TriggerConstantSmartContract runs the constant call ct.
+// A revert is returned as an *abi.RevertError decoded without custom errors
+func (g *GrpcClient) TriggerConstantSmartContract(ct *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	ctx, cancel := g.GetContext()
+	defer cancel()
+
+	result, err := g.Client.TriggerConstantContract(ctx, ct)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret := result.GetTransaction().GetRet(); len(ret) > 0 && ret[0].GetContractRet() == core.Transaction_Result_REVERT {
+		data := result.GetResult().GetMessage()
+		if constantResult := result.GetConstantResult(); len(constantResult) > 0 && len(constantResult[0]) > 0 {
+			data = constantResult[0]
+		}
+		return nil, abi.DecodeRevert(nil, data)
+	}
+
+	if err := ResultError(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ResultError returns the error of a failed result, revert data is returned as an *abi.RevertError
+func ResultError(tx *api.TransactionExtention) error {
+	if tx.GetResult().GetCode() == 0 {
+		return nil
+	}
+
+	message := tx.GetResult().GetMessage()
+	if constantResult := tx.GetConstantResult(); len(constantResult) > 0 && abi.IsRevertData(nil, constantResult[0]) {
+		return abi.DecodeRevert(nil, constantResult[0])
+	}
+	if abi.IsRevertData(nil, message) {
+		return abi.DecodeRevert(nil, message)
+	}
+
+	return fmt.Errorf("%s", string(message))
+}
+
+// TriggerContract builds, signs and broadcasts a call of method on contractAddress,
+// see BuildTriggerContract and SignAndBroadcast for the separate steps
+func (g *GrpcClient) TriggerContract(ks keystore.Signer, contractAddress, method string, paramData []byte, feeLimit, amount int64, tokenAmount *TokenAmount) (*transaction.Transaction, error) {
+	tx, err := g.BuildTriggerContract(ks.GetAddress().String(), contractAddress, method, paramData, feeLimit, amount, tokenAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.SignAndBroadcast(ks, tx)
+}
+
+// BuildTriggerContract returns the unsigned transaction calling method on contractAddress from
+// the from account, with the fee limit applied
+func (g *GrpcClient) BuildTriggerContract(from, contractAddress, method string, paramData []byte, feeLimit, amount int64, tokenAmount *TokenAmount) (*api.TransactionExtention, error) {
+	ct, err := NewTriggerSmartContract(from, contractAddress, method, paramData, amount, tokenAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.BuildContractTransaction(ct, feeLimit)
+}
+
+// NewTriggerSmartContract returns the contract calling method on contractAddress from the from account
+func NewTriggerSmartContract(from, contractAddress, method string, paramData []byte, amount int64, tokenAmount *TokenAmount) (*core.TriggerSmartContract, error) {
+	fromDesc, err := address.Base58ToAddress(from)
+	if err != nil {
+		return nil, err
+	}
+
 	contractDesc, err := address.Base58ToAddress(contractAddress)
 	if err != nil {
 		return nil, err
@@ -66,7 +136,7 @@ func (g *GrpcClient) TriggerContract(ks *keystore.Keystore, contractAddress, met
 	data := append(signature, paramData...)
 
 	ct := &core.TriggerSmartContract{
-		OwnerAddress:    ks.Address.Bytes(),
+		OwnerAddress:    fromDesc.Bytes(),
 		ContractAddress: contractDesc.Bytes(),
 		Data:            data,
 		CallValue:       amount,
@@ -80,10 +150,12 @@ func (g *GrpcClient) TriggerContract(ks *keystore.Keystore, contractAddress, met
 		}
 	}
 
-	return g.triggerContract(ks, ct, feeLimit)
+	return ct, nil
 }
 
-func (g *GrpcClient) triggerContract(ks *keystore.Keystore, ct *core.TriggerSmartContract, feeLimit int64) (*transaction.Transaction, error) {
+// BuildContractTransaction returns the unsigned transaction of ct with the fee limit
+// applied and the txid updated
+func (g *GrpcClient) BuildContractTransaction(ct *core.TriggerSmartContract, feeLimit int64) (*api.TransactionExtention, error) {
 	ctx, cancel := g.GetContext()
 	defer cancel()
 
@@ -92,8 +164,8 @@ func (g *GrpcClient) triggerContract(ks *keystore.Keystore, ct *core.TriggerSmar
 		return nil, err
 	}
 
-	if tx.Result.Code > 0 {
-		return nil, fmt.Errorf("%s", string(tx.Result.Message))
+	if err := ResultError(tx); err != nil {
+		return nil, err
 	}
 
 	if feeLimit > 0 {
@@ -103,7 +175,12 @@ func (g *GrpcClient) triggerContract(ks *keystore.Keystore, ct *core.TriggerSmar
 		}
 	}
 
-	signedTx, err := ks.SignTx(tx.Transaction)
+	return tx, nil
+}
+
+// SignAndBroadcast signs tx with signer and broadcasts it
+func (g *GrpcClient) SignAndBroadcast(signer keystore.Signer, tx *api.TransactionExtention) (*transaction.Transaction, error) {
+	signedTx, err := signer.SignTx(tx.Transaction)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +197,31 @@ func (g *GrpcClient) triggerContract(ks *keystore.Keystore, ct *core.TriggerSmar
 	}, nil
 }
 
+// DeployContract signs and broadcasts the deployment ct.
+// The contract address is known once the transaction is confirmed, see WaitForTransactionInfo
+func (g *GrpcClient) DeployContract(ks keystore.Signer, ct *core.CreateSmartContract, feeLimit int64) (*transaction.Transaction, error) {
+	ctx, cancel := g.GetContext()
+	defer cancel()
+
+	tx, err := g.Client.DeployContract(ctx, ct)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ResultError(tx); err != nil {
+		return nil, err
+	}
+
+	if feeLimit > 0 {
+		tx.Transaction.RawData.FeeLimit = feeLimit
+		if err := transaction.UpdateTxHash(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.SignAndBroadcast(ks, tx)
+}
+
 // GetContractABI return smartContract
 func (g *GrpcClient) GetContractABI(contractAddress string) (*core.SmartContract_ABI, error) {
 	var err error
@@ -142,3 +244,118 @@ func (g *GrpcClient) GetContractABI(contractAddress string) (*core.SmartContract
 
 	return sm.Abi, nil
 }
+
+// GetContractInfo returns the contract deployed at contractAddress with its
+// origin address, settings, code hash, name and ABI
+func (g *GrpcClient) GetContractInfo(contractAddress string) (*core.SmartContract, error) {
+	contractDesc, err := address.Base58ToAddress(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.GetContext()
+	defer cancel()
+
+	sm, err := g.Client.GetContract(ctx, GetMessageBytes(contractDesc))
+	if err != nil {
+		return nil, err
+	}
+	if sm == nil || len(sm.GetContractAddress()) == 0 {
+		return nil, fmt.Errorf("contract %s not found", contractAddress)
+	}
+
+	return sm, nil
+}
+
+// UpdateSetting sets the share of the energy paid by the callers of the contract, from 0 to 100
+func (g *GrpcClient) UpdateSetting(from, contractAddress string, consumeUserResourcePercent int64) (*api.TransactionExtention, error) {
+	if consumeUserResourcePercent < 0 || consumeUserResourcePercent > 100 {
+		return nil, fmt.Errorf("invalid consume user resource percent %d", consumeUserResourcePercent)
+	}
+
+	var err error
+	contract := &core.UpdateSettingContract{
+		ConsumeUserResourcePercent: consumeUserResourcePercent,
+	}
+
+	if contract.OwnerAddress, err = common.DecodeBase58(from); err != nil {
+		return nil, err
+	}
+	if contract.ContractAddress, err = common.DecodeBase58(contractAddress); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.GetContext()
+	defer cancel()
+
+	tx, err := g.Client.UpdateSetting(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkTransaction(tx)
+}
+
+// UpdateEnergyLimit sets the energy limit paid by the contract origin per call
+func (g *GrpcClient) UpdateEnergyLimit(from, contractAddress string, originEnergyLimit int64) (*api.TransactionExtention, error) {
+	if originEnergyLimit <= 0 {
+		return nil, fmt.Errorf("invalid origin energy limit %d", originEnergyLimit)
+	}
+
+	var err error
+	contract := &core.UpdateEnergyLimitContract{
+		OriginEnergyLimit: originEnergyLimit,
+	}
+
+	if contract.OwnerAddress, err = common.DecodeBase58(from); err != nil {
+		return nil, err
+	}
+	if contract.ContractAddress, err = common.DecodeBase58(contractAddress); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.GetContext()
+	defer cancel()
+
+	tx, err := g.Client.UpdateEnergyLimit(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkTransaction(tx)
+}
+
+// ClearContractABI removes the ABI published with the contract
+func (g *GrpcClient) ClearContractABI(from, contractAddress string) (*api.TransactionExtention, error) {
+	var err error
+	contract := &core.ClearABIContract{}
+
+	if contract.OwnerAddress, err = common.DecodeBase58(from); err != nil {
+		return nil, err
+	}
+	if contract.ContractAddress, err = common.DecodeBase58(contractAddress); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := g.GetContext()
+	defer cancel()
+
+	tx, err := g.Client.ClearContractABI(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkTransaction(tx)
+}
+
+func checkTransaction(tx *api.TransactionExtention) (*api.TransactionExtention, error) {
+	if proto.Size(tx) == 0 {
+		return nil, fmt.Errorf("bad transaction")
+	}
+
+	if tx.GetResult().GetCode() != 0 {
+		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/proto/api"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"google.golang.org/grpc"
+)
+
+// constantClient answers TriggerConstantContract with result
+type constantClient struct {
+	api.WalletClient
+	result *api.TransactionExtention
+}
+
+func (c *constantClient) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	return c.result, nil
+}
+
+func newConstantClient(result *api.TransactionExtention) *GrpcClient {
+	return &GrpcClient{
+		Client:      &constantClient{result: result},
+		grpcTimeout: time.Second,
+	}
+}
+
+func reverted(result *api.TransactionExtention) *api.TransactionExtention {
+	result.Transaction = &core.Transaction{
+		Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_REVERT}},
+	}
+	return result
+}
+
+func revertData(t *testing.T, sig, typ string, value interface{}) []byte {
+	t.Helper()
+
+	ty, err := abi.NewType(typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := ethabi.Arguments{{Type: ty}}.Pack(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return append(abi.MethodSignature(sig), packed...)
+}
+
+func TestTriggerConstantSmartContractRevert(t *testing.T) {
+	errorData := revertData(t, "Error(string)", "string", "not enough balance")
+	panicData := revertData(t, "Panic(uint256)", "uint256", big.NewInt(0x12))
+
+	tests := []struct {
+		name   string
+		result *api.TransactionExtention
+		reason string
+	}{
+		{
+			name:   "Error(string) in the constant result",
+			result: reverted(&api.TransactionExtention{ConstantResult: [][]byte{errorData}}),
+			reason: "not enough balance",
+		},
+		{
+			name:   "Panic(uint256) in the constant result",
+			result: reverted(&api.TransactionExtention{ConstantResult: [][]byte{panicData}}),
+			reason: "division or modulo by zero",
+		},
+		{
+			name: "message without constant result",
+			result: reverted(&api.TransactionExtention{
+				Result: &api.Return{Message: []byte("REVERT opcode executed")},
+			}),
+			reason: "REVERT opcode executed",
+		},
+		{
+			name: "failed result with revert data",
+			result: &api.TransactionExtention{
+				Result:         &api.Return{Code: api.Return_CONTRACT_EXE_ERROR},
+				ConstantResult: [][]byte{errorData},
+			},
+			reason: "not enough balance",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newConstantClient(test.result).TriggerConstantSmartContract(&core.TriggerSmartContract{})
+
+			var revert *abi.RevertError
+			if !errors.As(err, &revert) {
+				t.Fatalf("TriggerConstantSmartContract() = %v, want an *abi.RevertError", err)
+			}
+			if revert.Reason != test.reason {
+				t.Errorf("Reason = %q, want %q", revert.Reason, test.reason)
+			}
+		})
+	}
+}
+
+func TestTriggerConstantSmartContract(t *testing.T) {
+	result := &api.TransactionExtention{
+		Result:         &api.Return{Result: true},
+		ConstantResult: [][]byte{make([]byte, 32)},
+		Transaction: &core.Transaction{
+			Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_SUCCESS}},
+		},
+	}
+
+	got, err := newConstantClient(result).TriggerConstantSmartContract(&core.TriggerSmartContract{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != result {
+		t.Errorf("TriggerConstantSmartContract() = %v", got)
+	}
+
+	// a failure which is not a revert is returned as the node message
+	failed := &api.TransactionExtention{
+		Result: &api.Return{Code: api.Return_OTHER_ERROR, Message: []byte("class java.lang.NullPointerException")},
+	}
+	_, err = newConstantClient(failed).TriggerConstantSmartContract(&core.TriggerSmartContract{})
+	var revert *abi.RevertError
+	if err == nil || errors.As(err, &revert) || err.Error() != "class java.lang.NullPointerException" {
+		t.Errorf("TriggerConstantSmartContract() = %v", err)
+	}
+}
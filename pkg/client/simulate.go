@@ -0,0 +1,104 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/proto/api"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/craftto/go-tron/pkg/transaction"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// energyUsedField is the energy_used field of TransactionExtention returned
+// by recent nodes, unknown to the generated code
+const energyUsedField = 5
+
+// Simulation is the result of the dry run of a contract call
+type Simulation struct {
+	// EnergyUsed is the estimated energy, 0 when the node does not report it
+	EnergyUsed int64
+	// Result is the data returned by the call
+	Result []byte
+}
+
+// SimulateContract runs ct as a constant call without broadcasting it.
+// A revert is returned as an *abi.RevertError
+func (g *GrpcClient) SimulateContract(ct *core.TriggerSmartContract) (*Simulation, error) {
+	result, err := g.TriggerConstantSmartContract(ct)
+	if err != nil {
+		return nil, fmt.Errorf("simulation failed: %w", err)
+	}
+
+	simulation := &Simulation{
+		EnergyUsed: energyUsed(result),
+	}
+	if constantResult := result.GetConstantResult(); len(constantResult) > 0 {
+		simulation.Result = constantResult[0]
+	}
+
+	return simulation, nil
+}
+
+// SimulateTriggerContract runs a call of method on contractAddress from the from account
+// without broadcasting it
+func (g *GrpcClient) SimulateTriggerContract(from, contractAddress, method string, paramData []byte, amount int64, tokenAmount *TokenAmount) (*Simulation, error) {
+	ct, err := NewTriggerSmartContract(from, contractAddress, method, paramData, amount, tokenAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.SimulateContract(ct)
+}
+
+// SimulateAndTriggerContract runs TriggerContract only when its simulation succeeds
+func (g *GrpcClient) SimulateAndTriggerContract(ks keystore.Signer, contractAddress, method string, paramData []byte, feeLimit, amount int64, tokenAmount *TokenAmount) (*transaction.Transaction, *Simulation, error) {
+	ct, err := NewTriggerSmartContract(ks.GetAddress().String(), contractAddress, method, paramData, amount, tokenAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	simulation, err := g.SimulateContract(ct)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := g.BuildContractTransaction(ct, feeLimit)
+	if err != nil {
+		return nil, simulation, err
+	}
+
+	result, err := g.SignAndBroadcast(ks, tx)
+	if err != nil {
+		return nil, simulation, err
+	}
+
+	return result, simulation, nil
+}
+
+func energyUsed(tx *api.TransactionExtention) int64 {
+	unknown := tx.ProtoReflect().GetUnknown()
+
+	for len(unknown) > 0 {
+		num, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return 0
+		}
+		unknown = unknown[n:]
+
+		if num == energyUsedField && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(unknown)
+			if n < 0 {
+				return 0
+			}
+			return int64(v)
+		}
+
+		if n = protowire.ConsumeFieldValue(num, typ, unknown); n < 0 {
+			return 0
+		}
+		unknown = unknown[n:]
+	}
+
+	return 0
+}
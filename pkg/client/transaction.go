@@ -2,7 +2,9 @@ package client
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/craftto/go-tron/pkg/common"
 	"github.com/craftto/go-tron/pkg/proto/api"
@@ -10,6 +12,9 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrTransactionInfoNotFound is returned for the transactions not confirmed yet
+var ErrTransactionInfoNotFound = errors.New("transaction info not found")
+
 // TotalTransaction return total transciton in network
 func (g *GrpcClient) TotalTransaction() (*api.NumberMessage, error) {
 	ctx, cancel := g.GetContext()
@@ -62,9 +67,32 @@ func (g *GrpcClient) GetTransactionInfoByID(txHash string) (*core.TransactionInf
 	}
 
 	if !bytes.Equal(tx.Id, transactionID.Value) {
-		return nil, fmt.Errorf("transaction info not found")
-
+		return nil, ErrTransactionInfoNotFound
 	}
 
 	return tx, nil
 }
+
+// receiptPollInterval is the delay between two receipt lookups, about one block
+const receiptPollInterval = 3 * time.Second
+
+// WaitForTransactionInfo polls the receipt of txHash until it is found or timeout elapses,
+// the errors other than ErrTransactionInfoNotFound are returned immediately
+func (g *GrpcClient) WaitForTransactionInfo(txHash string, timeout time.Duration) (*core.TransactionInfo, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		info, err := g.GetTransactionInfoByID(txHash)
+		if err == nil {
+			return info, nil
+		}
+		if !errors.Is(err, ErrTransactionInfoNotFound) {
+			return nil, err
+		}
+
+		if time.Now().Add(receiptPollInterval).After(deadline) {
+			return nil, fmt.Errorf("transaction %s not confirmed after %s: %v", txHash, timeout, err)
+		}
+		time.Sleep(receiptPollInterval)
+	}
+}
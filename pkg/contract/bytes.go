@@ -28,12 +28,14 @@ func ParseString(data []byte) (string, error) {
 			return "", errors.New("Cannot parse data")
 		}
 
+		if !n.IsUint64() || n.Uint64() > uint64(len(dataStr)-128)/2 {
+			return "", errors.New("Cannot parse data")
+		}
+
 		l := n.Uint64()
-		if 2*int(l) <= len(dataStr)-128 {
-			b, err := hex.DecodeString(dataStr[128 : 128+2*l])
-			if err == nil {
-				return string(b), nil
-			}
+		b, err := hex.DecodeString(dataStr[128 : 128+2*l])
+		if err == nil {
+			return string(b), nil
 		}
 	} else if len(dataStr) == 64 {
 		// allow string properties as 32 bytes of UTF-8 data
@@ -78,6 +80,11 @@ func ParseStringArray(data []byte) ([]string, error) {
 	size := ParseInt(data[:32])
 	data = data[32:]
 
+	// each element takes 32 bytes
+	if !size.IsInt64() || size.Int64() > int64(len(data)/32) {
+		return nil, errors.New("Cannot parse data")
+	}
+
 	arr := make([]string, 0, size.Int64())
 	for i := 0; i < int(size.Int64()); i++ {
 		index := 32 * i
@@ -101,6 +108,11 @@ func ParseIntArray(data []byte) ([]*big.Int, error) {
 	size := ParseInt(data[:32])
 	data = data[32:]
 
+	// each element takes 32 bytes
+	if !size.IsInt64() || size.Int64() > int64(len(data)/32) {
+		return nil, errors.New("Cannot parse data")
+	}
+
 	arr := make([]*big.Int, 0, size.Int64())
 	for i := 0; i < int(size.Int64()); i++ {
 		index := 32 * i
@@ -119,6 +131,11 @@ func ParseAddressArray(data []byte) ([]address.Address, error) {
 	size := ParseInt(data[:32])
 	data = data[32:]
 
+	// each element takes 32 bytes
+	if !size.IsInt64() || size.Int64() > int64(len(data)/32) {
+		return nil, errors.New("Cannot parse data")
+	}
+
 	arr := make([]address.Address, 0, size.Int64())
 	for i := 0; i < int(size.Int64()); i++ {
 		index := 32 * i
@@ -0,0 +1,196 @@
+package contract
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/transaction"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var (
+	defaultFeeLimit int64 = 100_000_000
+)
+
+// Contract calls the methods of a deployed contract by name through its ABI
+type Contract struct {
+	Address address.Address
+	ABI     *ethabi.ABI
+	// FeeLimit applied to transactions, in SUN
+	FeeLimit int64
+	*client.GrpcClient
+}
+
+// New returns the contract at contractAddr described by abiJSON
+func New(g *client.GrpcClient, contractAddr, abiJSON string) (*Contract, error) {
+	a, err := abi.ParseJSON(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return newContract(g, contractAddr, a)
+}
+
+// NewWithABI returns the contract at contractAddr described by the parsed ABI a
+func NewWithABI(g *client.GrpcClient, contractAddr string, a *ethabi.ABI) (*Contract, error) {
+	return newContract(g, contractAddr, a)
+}
+
+// NewFromChain returns the contract at contractAddr with the ABI published on chain
+func NewFromChain(g *client.GrpcClient, contractAddr string) (*Contract, error) {
+	smartContractABI, err := g.GetContractABI(contractAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := abi.FromSmartContractABI(smartContractABI)
+	if err != nil {
+		return nil, err
+	}
+
+	return newContract(g, contractAddr, a)
+}
+
+func newContract(g *client.GrpcClient, contractAddr string, a *ethabi.ABI) (*Contract, error) {
+	addr, err := address.Parse(contractAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Contract{
+		Address:    addr,
+		ABI:        a,
+		FeeLimit:   defaultFeeLimit,
+		GrpcClient: g,
+	}, nil
+}
+
+// Method returns the method named method, or with the signature method such as
+// "transfer(address,uint256)", that accepts args. Overloaded methods are
+// resolved by their number of arguments and the arguments they can pack
+func (c *Contract) Method(method string, args ...interface{}) (*ethabi.Method, []byte, error) {
+	if strings.Contains(method, "(") {
+		sig := strings.ReplaceAll(method, " ", "")
+		for _, m := range c.ABI.Methods {
+			if m.Sig == sig {
+				m := m
+				packed, err := packMethod(&m, args)
+				if err != nil {
+					return nil, nil, err
+				}
+				return &m, packed, nil
+			}
+		}
+		return nil, nil, fmt.Errorf("method %s not found", method)
+	}
+
+	candidates := make([]ethabi.Method, 0)
+	for _, m := range c.ABI.Methods {
+		if m.RawName == method && len(m.Inputs) == len(args) {
+			candidates = append(candidates, m)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Sig < candidates[j].Sig
+	})
+
+	if len(candidates) == 0 {
+		return nil, nil, fmt.Errorf("method %s with %d arguments not found", method, len(args))
+	}
+
+	var (
+		found    *ethabi.Method
+		packed   []byte
+		firstErr error
+	)
+	for i := range candidates {
+		p, err := packMethod(&candidates[i], args)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if found != nil {
+			return nil, nil, fmt.Errorf("method %s is ambiguous between %s and %s, use the signature", method, found.Sig, candidates[i].Sig)
+		}
+		found, packed = &candidates[i], p
+	}
+
+	if found == nil {
+		return nil, nil, firstErr
+	}
+
+	return found, packed, nil
+}
+
+// Call runs a constant call of method and returns its outputs,
+// with addresses returned as address.Address
+func (c *Contract) Call(method string, args ...interface{}) ([]interface{}, error) {
+	m, packed, err := c.Method(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.TriggerConstantContract(c.Address.String(), "", m.Sig, packed)
+	if err != nil {
+		return nil, c.revertError(err)
+	}
+
+	constantResult := result.Transaction.GetConstantResult()
+	if len(constantResult) == 0 {
+		return nil, fmt.Errorf("empty result for %s", m.Sig)
+	}
+
+	return DecodeArguments(m.Outputs, constantResult[0])
+}
+
+// Transact signs and broadcasts a call of method
+func (c *Contract) Transact(ks keystore.Signer, method string, args ...interface{}) (*transaction.Transaction, error) {
+	return c.TransactWithValue(ks, 0, nil, method, args...)
+}
+
+// TransactWithValue signs and broadcasts a call of method sending callValue SUN and tokenAmount
+func (c *Contract) TransactWithValue(ks keystore.Signer, callValue int64, tokenAmount *client.TokenAmount, method string, args ...interface{}) (*transaction.Transaction, error) {
+	m, packed, err := c.Method(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := c.TriggerContract(ks, c.Address.String(), m.Sig, packed, c.FeeLimit, callValue, tokenAmount)
+	if err != nil {
+		return nil, c.revertError(err)
+	}
+
+	return tx, nil
+}
+
+// revertError decodes the custom errors of the contract ABI in a revert
+func (c *Contract) revertError(err error) error {
+	var revert *abi.RevertError
+	if errors.As(err, &revert) && revert.CustomError == nil {
+		return abi.DecodeRevert(c.ABI, revert.Data)
+	}
+
+	return err
+}
+
+func packMethod(m *ethabi.Method, args []interface{}) ([]byte, error) {
+	values, err := abi.ConvertArguments(m.Inputs, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", m.Sig, err)
+	}
+
+	packed, err := m.Inputs.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", m.Sig, err)
+	}
+
+	return packed, nil
+}
@@ -0,0 +1,203 @@
+package contract
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Decode decodes the return data of a call returning the ABI types,
+// such as "bool", "bytes32[2]", "string" or "(address,uint256)[]".
+// Addresses are returned as address.Address, see abi.FromEVM
+func Decode(types []string, data []byte) ([]interface{}, error) {
+	args := make(ethabi.Arguments, 0, len(types))
+	for _, t := range types {
+		ty, err := abi.NewType(t)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, ethabi.Argument{Type: ty})
+	}
+
+	return DecodeArguments(args, data)
+}
+
+// DecodeArguments decodes the return data of a call returning args.
+// The offsets and lengths are checked against data before decoding,
+// malformed data is reported as an error
+func DecodeArguments(args ethabi.Arguments, data []byte) (values []interface{}, err error) {
+	if len(args) > 0 && len(data) == 0 {
+		return nil, fmt.Errorf("empty return data, expected %d values", len(args))
+	}
+
+	if err := checkArguments(args, data); err != nil {
+		return nil, fmt.Errorf("malformed return data: %v", err)
+	}
+
+	// checkBounds covers the layout, this only guards against a decoder bug
+	defer func() {
+		if r := recover(); r != nil {
+			values, err = nil, fmt.Errorf("malformed return data: %v", r)
+		}
+	}()
+
+	if values, err = args.Unpack(data); err != nil {
+		return nil, fmt.Errorf("malformed return data: %v", err)
+	}
+
+	return abi.ConvertOutputs(args, values), nil
+}
+
+// checkArguments checks the offsets and lengths of the encoding of args in data
+func checkArguments(args ethabi.Arguments, data []byte) error {
+	pos := 0
+	for _, arg := range args {
+		if err := checkBounds(arg.Type, data, 0, pos); err != nil {
+			return fmt.Errorf("%s: %v", arg.Type.String(), err)
+		}
+		pos += headSize(arg.Type)
+	}
+
+	return nil
+}
+
+// checkBounds checks that the value of type t with its head at pos, and its
+// offsets relative to base, lie within data
+func checkBounds(t ethabi.Type, data []byte, base, pos int) error {
+	if !isDynamic(t) {
+		if pos+headSize(t) > len(data) {
+			return fmt.Errorf("value at %d exceeds %d bytes", pos, len(data))
+		}
+		return nil
+	}
+
+	offset, err := readWord(data, pos)
+	if err != nil {
+		return err
+	}
+	if offset > uint64(len(data)-base) {
+		return fmt.Errorf("offset %d exceeds %d bytes", offset, len(data))
+	}
+	start := base + int(offset)
+
+	switch t.T {
+	case ethabi.StringTy, ethabi.BytesTy:
+		length, err := readWord(data, start)
+		if err != nil {
+			return err
+		}
+		if length > uint64(len(data)-start-32) {
+			return fmt.Errorf("length %d at %d exceeds %d bytes", length, start, len(data))
+		}
+
+	case ethabi.SliceTy:
+		length, err := readWord(data, start)
+		if err != nil {
+			return err
+		}
+		// zero sized elements are counted as words to bound the allocations
+		elemSize := uint64(headSize(*t.Elem))
+		if elemSize == 0 {
+			elemSize = 32
+		}
+		if length > uint64(len(data)-start-32)/elemSize {
+			return fmt.Errorf("length %d at %d exceeds %d bytes", length, start, len(data))
+		}
+		return checkElements(*t.Elem, int(length), data, start+32)
+
+	case ethabi.ArrayTy:
+		return checkElements(*t.Elem, t.Size, data, start)
+
+	case ethabi.TupleTy:
+		head := start
+		for _, elem := range t.TupleElems {
+			if err := checkBounds(*elem, data, start, head); err != nil {
+				return err
+			}
+			head += headSize(*elem)
+		}
+	}
+
+	return nil
+}
+
+// checkElements checks the n elements of type t encoded from start
+func checkElements(t ethabi.Type, n int, data []byte, start int) error {
+	size := headSize(t)
+	for i := 0; i < n; i++ {
+		if err := checkBounds(t, data, start, start+i*size); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readWord reads the 32 bytes word at pos as an offset or a length
+func readWord(data []byte, pos int) (uint64, error) {
+	if pos < 0 || pos+32 > len(data) {
+		return 0, fmt.Errorf("word at %d exceeds %d bytes", pos, len(data))
+	}
+
+	word := new(big.Int).SetBytes(data[pos : pos+32])
+	if !word.IsUint64() || word.Uint64() > math.MaxInt32 {
+		return 0, fmt.Errorf("invalid offset or length %s at %d", word, pos)
+	}
+
+	return word.Uint64(), nil
+}
+
+// headSize returns the size of the head of type t, 32 bytes for the offset of dynamic types
+func headSize(t ethabi.Type) int {
+	if isDynamic(t) {
+		return 32
+	}
+
+	switch t.T {
+	case ethabi.ArrayTy:
+		return t.Size * headSize(*t.Elem)
+	case ethabi.TupleTy:
+		size := 0
+		for _, elem := range t.TupleElems {
+			size += headSize(*elem)
+		}
+		return size
+	}
+
+	return 32
+}
+
+func isDynamic(t ethabi.Type) bool {
+	switch t.T {
+	case ethabi.StringTy, ethabi.BytesTy, ethabi.SliceTy:
+		return true
+	case ethabi.ArrayTy:
+		return isDynamic(*t.Elem)
+	case ethabi.TupleTy:
+		for _, elem := range t.TupleElems {
+			if isDynamic(*elem) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SingleOutput returns the only value of the outputs of a call as a T
+func SingleOutput[T any](out []interface{}) (T, error) {
+	var v T
+	if len(out) != 1 {
+		return v, fmt.Errorf("expected 1 output, got %d", len(out))
+	}
+
+	v, ok := out[0].(T)
+	if !ok {
+		return v, fmt.Errorf("unexpected output type %T", out[0])
+	}
+
+	return v, nil
+}
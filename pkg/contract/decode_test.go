@@ -0,0 +1,114 @@
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const testAddress = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+
+// decodeCases are the outputs and values of the valid encodings
+var decodeCases = []struct {
+	types  []string
+	values []interface{}
+}{
+	{[]string{"uint256", "bool"}, []interface{}{big.NewInt(42), true}},
+	{[]string{"bytes"}, []interface{}{[]byte("hello tron")}},
+	{[]string{"string", "string"}, []interface{}{"a", "bc"}},
+	{[]string{"uint256[]"}, []interface{}{[]interface{}{1, 2, 3}}},
+	{[]string{"uint8[2][]"}, []interface{}{[]interface{}{[]interface{}{1, 2}, []interface{}{3, 4}}}},
+	{[]string{"string[]"}, []interface{}{[]interface{}{"x", "yz"}}},
+	{[]string{"(address,uint256)"}, []interface{}{[]interface{}{testAddress, 7}}},
+	{[]string{"(address,uint256)[]"}, []interface{}{[]interface{}{[]interface{}{testAddress, 1}, []interface{}{testAddress, 2}}}},
+	{[]string{"(bool,bytes,uint8[])", "address"}, []interface{}{[]interface{}{true, []byte{1, 2}, []interface{}{5}}, testAddress}},
+	{[]string{"bytes[2]", "uint256"}, []interface{}{[]interface{}{[]byte{1}, []byte{2, 3}}, 9}},
+}
+
+func testArguments(t testing.TB, types []string) ethabi.Arguments {
+	args := make(ethabi.Arguments, 0, len(types))
+	for _, typ := range types {
+		ty, err := abi.NewType(typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, ethabi.Argument{Type: ty})
+	}
+
+	return args
+}
+
+func testEncode(t testing.TB, args ethabi.Arguments, values []interface{}) []byte {
+	evmValues := make([]interface{}, len(values))
+	for i, v := range values {
+		var err error
+		if evmValues[i], err = abi.ToEVM(args[i].Type, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := args.Pack(evmValues...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestDecodeArguments(t *testing.T) {
+	for _, test := range decodeCases {
+		args := testArguments(t, test.types)
+		data := testEncode(t, args, test.values)
+
+		if _, err := DecodeArguments(args, data); err != nil {
+			t.Errorf("%v: %v", test.types, err)
+		}
+
+		// every truncation of the data is malformed
+		for n := 0; n < len(data); n += 32 {
+			if _, err := DecodeArguments(args, data[:n]); err == nil {
+				t.Errorf("%v: decoded %d of %d bytes", test.types, n, len(data))
+			}
+		}
+	}
+}
+
+func TestDecodeArgumentsOffsets(t *testing.T) {
+	args := testArguments(t, []string{"bytes"})
+	data := testEncode(t, args, []interface{}{[]byte("hello")})
+
+	for _, offset := range []byte{0x01, 0x60, 0xff} {
+		bad := append([]byte{}, data...)
+		bad[31] = offset
+		if _, err := DecodeArguments(args, bad); err == nil {
+			t.Errorf("decoded offset %#x", offset)
+		}
+	}
+
+	bad := append([]byte{}, data...)
+	bad[63] = 0xff
+	if _, err := DecodeArguments(args, bad); err == nil {
+		t.Error("decoded length 0xff")
+	}
+}
+
+func FuzzDecodeArguments(f *testing.F) {
+	argsList := make([]ethabi.Arguments, 0, len(decodeCases))
+	for _, test := range decodeCases {
+		args := testArguments(f, test.types)
+		argsList = append(argsList, args)
+		f.Add(testEncode(f, args, test.values))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, args := range argsList {
+			// the unpacking must not panic once the bounds are checked,
+			// it is called without the recover of DecodeArguments
+			if checkArguments(args, data) == nil {
+				_, _ = args.Unpack(data)
+			}
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package contract
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/common"
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/craftto/go-tron/pkg/transaction"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	defaultOriginEnergyLimit int64 = 10_000_000
+	defaultDeployTimeout           = time.Minute
+)
+
+// DeployOptions describes a contract deployment
+type DeployOptions struct {
+	Name string
+	// Bytecode is the hex creation code, it may hold library placeholders
+	Bytecode string
+	// ABI is the contract ABI in the solidity JSON format
+	ABI string
+	// ConstructorArgs are the ABI encoded constructor arguments, see abi.GetParams
+	ConstructorArgs []byte
+	// Libraries maps library names to their address for linking, see LinkBytecode
+	Libraries map[string]string
+	// ConsumeUserResourcePercent is the share of the energy paid by callers
+	ConsumeUserResourcePercent int64
+	// OriginEnergyLimit is the energy limit paid by the deployer per call, 10_000_000 by default
+	OriginEnergyLimit int64
+	// CallValue is the amount of SUN sent to the constructor
+	CallValue int64
+	// TokenValue is the TRC10 amount sent to the constructor
+	TokenValue *client.TokenAmount
+	// FeeLimit of the deployment in SUN, 100_000_000 by default
+	FeeLimit int64
+	// Timeout of the confirmation, one minute by default
+	Timeout time.Duration
+}
+
+// Deploy deploys the contract described by opts, waits for its confirmation
+// and returns its address
+func Deploy(g *client.GrpcClient, ks keystore.Signer, opts *DeployOptions) (address.Address, *transaction.TransactionReceipt, error) {
+	bytecode, err := LinkBytecode(opts.Bytecode, opts.Libraries)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	code, err := common.Hex2Bytes(bytecode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid bytecode: %v", err)
+	}
+
+	contractABI, err := abi.JSONToSmartContractABI(opts.ABI)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	originEnergyLimit := opts.OriginEnergyLimit
+	if originEnergyLimit == 0 {
+		originEnergyLimit = defaultOriginEnergyLimit
+	}
+
+	ct := &core.CreateSmartContract{
+		OwnerAddress: ks.GetAddress().Bytes(),
+		NewContract: &core.SmartContract{
+			OriginAddress:              ks.GetAddress().Bytes(),
+			Abi:                        contractABI,
+			Bytecode:                   append(code, opts.ConstructorArgs...),
+			CallValue:                  opts.CallValue,
+			ConsumeUserResourcePercent: opts.ConsumeUserResourcePercent,
+			Name:                       opts.Name,
+			OriginEnergyLimit:          originEnergyLimit,
+		},
+	}
+
+	if opts.TokenValue != nil {
+		ct.CallTokenValue = opts.TokenValue.Amount
+		if ct.TokenId, err = strconv.ParseInt(opts.TokenValue.TokenId, 10, 64); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	feeLimit := opts.FeeLimit
+	if feeLimit == 0 {
+		feeLimit = defaultFeeLimit
+	}
+
+	tx, err := g.DeployContract(ks, ct, feeLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultDeployTimeout
+	}
+
+	info, err := g.WaitForTransactionInfo(tx.TransactionHash, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receipt, err := transaction.GetTransactionReceipt(info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if info.GetResult() != core.TransactionInfo_SUCESS {
+		if a, err := abi.ParseJSON(opts.ABI); err == nil {
+			if revert := receipt.RevertError(a); revert != nil {
+				return nil, receipt, revert
+			}
+		}
+		return nil, receipt, fmt.Errorf("deployment failed: %s", string(info.GetResMessage()))
+	}
+
+	if len(info.GetContractAddress()) > 0 {
+		return address.Address(info.GetContractAddress()), receipt, nil
+	}
+
+	contractAddr, err := address.ContractAddress(tx.Transaction.GetTxid(), ks.GetAddress())
+	if err != nil {
+		return nil, receipt, err
+	}
+
+	return contractAddr, receipt, nil
+}
+
+// LinkBytecode replaces the library placeholders of bytecode by the library addresses.
+// Libraries are named as in the placeholders, "Lib" or "contracts/Lib.sol:Lib" for the
+// legacy "__Lib___..." placeholders, the fully qualified name for the "__$hash$__" ones
+func LinkBytecode(bytecode string, libraries map[string]string) (string, error) {
+	bytecode = strings.TrimPrefix(bytecode, "0x")
+
+	for name, lib := range libraries {
+		addr, err := address.Parse(lib)
+		if err != nil {
+			return "", fmt.Errorf("library %s: %v", name, err)
+		}
+		hexAddr := strings.TrimPrefix(common.Bytes2Hex(addr[1:]), "0x")
+
+		hash := strings.TrimPrefix(common.Bytes2Hex(crypto.Keccak256([]byte(name))), "0x")
+		bytecode = strings.ReplaceAll(bytecode, "__$"+hash[:34]+"$__", hexAddr)
+
+		legacy := name
+		if len(legacy) > 36 {
+			legacy = legacy[:36]
+		}
+		legacy = "__" + legacy + strings.Repeat("_", 38-len(legacy))
+		bytecode = strings.ReplaceAll(bytecode, legacy, hexAddr)
+	}
+
+	if i := strings.Index(bytecode, "__"); i >= 0 {
+		end := i + 40
+		if end > len(bytecode) {
+			end = len(bytecode)
+		}
+		return "", fmt.Errorf("unlinked library %s", bytecode[i:end])
+	}
+
+	return bytecode, nil
+}
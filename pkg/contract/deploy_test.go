@@ -0,0 +1,124 @@
+package contract
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testAddressHex is testAddress without the 0x41 prefix, as linked in the bytecode
+const testAddressHex = "a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+
+func hashPlaceholder(name string) string {
+	hash := crypto.Keccak256Hash([]byte(name)).Hex()
+	return "__$" + hash[2:36] + "$__"
+}
+
+func TestLinkBytecode(t *testing.T) {
+	const (
+		lib       = "contracts/SafeMath.sol:SafeMath"
+		longLib   = "contracts/libraries/FixedPointMathematics.sol:FixedPointMathematics"
+		legacyLib = "__SafeMath______________________________"
+	)
+
+	tests := []struct {
+		name      string
+		bytecode  string
+		libraries map[string]string
+		want      string
+	}{
+		{
+			name:      "hashed placeholder",
+			bytecode:  "0x6080" + hashPlaceholder(lib) + "5050" + hashPlaceholder(lib),
+			libraries: map[string]string{lib: testAddress},
+			want:      "6080" + testAddressHex + "5050" + testAddressHex,
+		},
+		{
+			name:      "legacy placeholder",
+			bytecode:  "6080" + legacyLib + "5050",
+			libraries: map[string]string{"SafeMath": testAddress},
+			want:      "6080" + testAddressHex + "5050",
+		},
+		{
+			name:      "legacy placeholder of a truncated qualified name",
+			bytecode:  "6080__" + longLib[:36] + "__5050",
+			libraries: map[string]string{longLib: testAddress},
+			want:      "6080" + testAddressHex + "5050",
+		},
+		{
+			name:      "hex library address",
+			bytecode:  "6080" + hashPlaceholder(lib),
+			libraries: map[string]string{lib: "41" + testAddressHex},
+			want:      "6080" + testAddressHex,
+		},
+		{
+			name:      "unused library",
+			bytecode:  "60806040",
+			libraries: map[string]string{lib: testAddress},
+			want:      "60806040",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := LinkBytecode(test.bytecode, test.libraries)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("LinkBytecode() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLinkBytecodeMissingLibrary(t *testing.T) {
+	const lib = "contracts/SafeMath.sol:SafeMath"
+
+	tests := []struct {
+		name        string
+		bytecode    string
+		libraries   map[string]string
+		placeholder string
+	}{
+		{
+			name:        "no libraries",
+			bytecode:    "6080" + hashPlaceholder(lib) + "5050",
+			placeholder: hashPlaceholder(lib),
+		},
+		{
+			name:        "other library",
+			bytecode:    "6080" + hashPlaceholder(lib) + "5050",
+			libraries:   map[string]string{"contracts/Math.sol:Math": testAddress},
+			placeholder: hashPlaceholder(lib),
+		},
+		{
+			// the hashed placeholders need the fully qualified name
+			name:        "short name of a hashed placeholder",
+			bytecode:    "6080" + hashPlaceholder(lib) + "5050",
+			libraries:   map[string]string{"SafeMath": testAddress},
+			placeholder: hashPlaceholder(lib),
+		},
+		{
+			name:        "second library",
+			bytecode:    hashPlaceholder(lib) + "__Math__________________________________",
+			libraries:   map[string]string{lib: testAddress},
+			placeholder: "__Math__________________________________",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LinkBytecode(test.bytecode, test.libraries)
+			if err == nil || !strings.Contains(err.Error(), test.placeholder) {
+				t.Errorf("LinkBytecode() = %v, want an unlinked %s error", err, test.placeholder)
+			}
+		})
+	}
+
+	_, err := LinkBytecode(hashPlaceholder(lib), map[string]string{lib: "TInvalid"})
+	if err == nil || !strings.Contains(err.Error(), lib) {
+		t.Errorf("LinkBytecode(invalid address) = %v", err)
+	}
+}
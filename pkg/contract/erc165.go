@@ -0,0 +1,69 @@
+package contract
+
+import (
+	"errors"
+
+	"github.com/craftto/go-tron/pkg/abi"
+)
+
+// InterfaceIDERC165 is the ERC165 interface id of supportsInterface(bytes4)
+var InterfaceIDERC165 = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+
+var interfaceIDInvalid = [4]byte{0xff, 0xff, 0xff, 0xff}
+
+// SupportsInterface reports whether the contract implements the interface id with
+// the ERC165 detection: the contract must declare ERC165 and reject the 0xffffffff id
+// before id is queried. Contracts without ERC165 support implement no interface
+func (c *Contract) SupportsInterface(id [4]byte) (bool, error) {
+	checks := []struct {
+		id   [4]byte
+		want bool
+	}{
+		{InterfaceIDERC165, true},
+		{interfaceIDInvalid, false},
+	}
+
+	for _, check := range checks {
+		supported, err := c.supportsInterface(check.id)
+		if err != nil || supported != check.want {
+			return false, err
+		}
+	}
+
+	if id == InterfaceIDERC165 {
+		return true, nil
+	}
+
+	return c.supportsInterface(id)
+}
+
+// supportsInterface calls supportsInterface(bytes4), a revert or an invalid
+// return data is reported as not supported
+func (c *Contract) supportsInterface(id [4]byte) (bool, error) {
+	param := make([]byte, 32)
+	copy(param, id[:])
+
+	result, err := c.TriggerConstantContract(c.Address.String(), "", "supportsInterface(bytes4)", param)
+	if err != nil {
+		var revert *abi.RevertError
+		if errors.As(err, &revert) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	constantResult := result.Transaction.GetConstantResult()
+	if len(constantResult) == 0 || len(constantResult[0]) < 32 {
+		return false, nil
+	}
+
+	// the result must be the bool true, ABI encoded
+	word := constantResult[0][:32]
+	for _, b := range word[:31] {
+		if b != 0 {
+			return false, nil
+		}
+	}
+
+	return word[31] == 1, nil
+}
@@ -0,0 +1,443 @@
+package dex
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/contract"
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/craftto/go-tron/pkg/transaction"
+	"github.com/craftto/go-tron/pkg/trc20"
+)
+
+// TRX stands for TRX at the ends of a swap path, it is swapped through the wrapped TRX of the router
+const TRX = "TRX"
+
+var (
+	// DefaultSlippage of the swaps in basis points, 0.5%
+	DefaultSlippage int64 = 50
+	// DefaultDeadline of the swaps after their broadcast
+	DefaultDeadline = 20 * time.Minute
+	// DefaultApprovalTimeout is the time waited for the confirmation of an approval
+	DefaultApprovalTimeout = time.Minute
+)
+
+// ErrPairNotFound is returned when the factory has no pair for the tokens
+var ErrPairNotFound = errors.New("pair not found")
+
+// Config of a V2 style DEX
+type Config struct {
+	// Router is the Router02 contract
+	Router string
+	// Factory is the factory contract, the one of the router when empty
+	Factory string
+	// Fee of the pairs in basis points, DefaultFee when nil
+	Fee *int64
+}
+
+// DEX swaps through the router and the pairs of a Uniswap V2 fork such as SunSwap V2
+type DEX struct {
+	Router  *contract.Contract
+	Factory *contract.Contract
+	// WTRX is the wrapped TRX token of the router
+	WTRX address.Address
+	Fee  int64
+	// ApprovalTimeout is the time waited for the confirmation of the approvals of the swaps
+	ApprovalTimeout time.Duration
+	*client.GrpcClient
+}
+
+// Reserves of a pair
+type Reserves struct {
+	Pair               address.Address
+	Token0             address.Address
+	Token1             address.Address
+	Reserve0           *big.Int
+	Reserve1           *big.Int
+	BlockTimestampLast uint32
+}
+
+// SwapOptions of a swap, the zero value uses the defaults
+type SwapOptions struct {
+	// Slippage tolerated from the quote in basis points, DefaultSlippage when zero
+	Slippage int64
+	// Deadline of the swap after its broadcast, DefaultDeadline when zero
+	Deadline time.Duration
+	// Recipient of the bought tokens, the signer when empty
+	Recipient string
+}
+
+func New(g *client.GrpcClient, cfg Config) (*DEX, error) {
+	fee := DefaultFee
+	if cfg.Fee != nil {
+		fee = *cfg.Fee
+	}
+	if err := checkFee(fee); err != nil {
+		return nil, err
+	}
+
+	router, err := contract.NewWithABI(g, cfg.Router, Router)
+	if err != nil {
+		return nil, fmt.Errorf("router: %v", err)
+	}
+
+	factoryAddr := cfg.Factory
+	if factoryAddr == "" {
+		out, err := router.Call("factory")
+		if err != nil {
+			return nil, err
+		}
+		addr, err := contract.SingleOutput[address.Address](out)
+		if err != nil {
+			return nil, err
+		}
+		factoryAddr = addr.String()
+	}
+
+	factory, err := contract.NewWithABI(g, factoryAddr, Factory)
+	if err != nil {
+		return nil, fmt.Errorf("factory: %v", err)
+	}
+
+	out, err := router.Call("WETH")
+	if err != nil {
+		return nil, err
+	}
+	wtrx, err := contract.SingleOutput[address.Address](out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DEX{
+		Router:          router,
+		Factory:         factory,
+		WTRX:            wtrx,
+		Fee:             fee,
+		ApprovalTimeout: DefaultApprovalTimeout,
+		GrpcClient:      g,
+	}, nil
+}
+
+// GetPair returns the pair of the tokens, ErrPairNotFound when the factory has none
+func (d *DEX) GetPair(tokenA, tokenB string) (address.Address, error) {
+	out, err := d.Factory.Call("getPair", d.token(tokenA), d.token(tokenB))
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := contract.SingleOutput[address.Address](out)
+	if err != nil {
+		return nil, err
+	}
+	if isZero(pair) {
+		return nil, ErrPairNotFound
+	}
+
+	return pair, nil
+}
+
+// GetReserves returns the tokens and the reserves of pair
+func (d *DEX) GetReserves(pair string) (*Reserves, error) {
+	c, err := contract.NewWithABI(d.GrpcClient, pair, Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	reserves := &Reserves{Pair: c.Address}
+
+	out, err := c.Call("token0")
+	if err != nil {
+		return nil, err
+	}
+	if reserves.Token0, err = contract.SingleOutput[address.Address](out); err != nil {
+		return nil, err
+	}
+
+	out, err = c.Call("token1")
+	if err != nil {
+		return nil, err
+	}
+	if reserves.Token1, err = contract.SingleOutput[address.Address](out); err != nil {
+		return nil, err
+	}
+
+	out, err = c.Call("getReserves")
+	if err != nil {
+		return nil, err
+	}
+	if len(out) != 3 {
+		return nil, fmt.Errorf("expected 3 outputs, got %d", len(out))
+	}
+
+	var ok [3]bool
+	reserves.Reserve0, ok[0] = out[0].(*big.Int)
+	reserves.Reserve1, ok[1] = out[1].(*big.Int)
+	reserves.BlockTimestampLast, ok[2] = out[2].(uint32)
+	if !ok[0] || !ok[1] || !ok[2] {
+		return nil, fmt.Errorf("unexpected output types %T, %T, %T", out[0], out[1], out[2])
+	}
+
+	return reserves, nil
+}
+
+// Hops returns the reserves of the pairs along path, see GetAmountsOut and GetAmountsIn
+func (d *DEX) Hops(path []string) ([]Hop, error) {
+	tokens, _, _, err := d.resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	hops := make([]Hop, 0, len(tokens)-1)
+	for i := 0; i < len(tokens)-1; i++ {
+		pair, err := d.GetPair(tokens[i].String(), tokens[i+1].String())
+		if err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", path[i], path[i+1], err)
+		}
+
+		reserves, err := d.GetReserves(pair.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if tokens[i].String() == reserves.Token0.String() {
+			hops = append(hops, Hop{In: reserves.Reserve0, Out: reserves.Reserve1})
+		} else {
+			hops = append(hops, Hop{In: reserves.Reserve1, Out: reserves.Reserve0})
+		}
+	}
+
+	return hops, nil
+}
+
+// QuoteOut returns the amounts along path when selling amountIn, from the current reserves
+func (d *DEX) QuoteOut(amountIn *big.Int, path []string) ([]*big.Int, error) {
+	hops, err := d.Hops(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetAmountsOut(amountIn, hops, d.Fee)
+}
+
+// QuoteIn returns the amounts along path when buying amountOut, from the current reserves
+func (d *DEX) QuoteIn(amountOut *big.Int, path []string) ([]*big.Int, error) {
+	hops, err := d.Hops(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetAmountsIn(amountOut, hops, d.Fee)
+}
+
+// SwapExactIn sells amountIn of the first token of path for at least the quote lowered by the slippage.
+// path holds token addresses, TRX may be its first or last element.
+// The router is approved for amountIn first when its allowance is too low
+func (d *DEX) SwapExactIn(ks keystore.Signer, amountIn *big.Int, path []string, opts *SwapOptions) (*transaction.Transaction, error) {
+	tokens, trxIn, trxOut, err := d.resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if opts, err = withDefaults(opts, ks); err != nil {
+		return nil, err
+	}
+
+	// the approval may wait for its confirmation, the swap is quoted after it
+	if !trxIn {
+		if err := d.EnsureAllowance(ks, path[0], amountIn); err != nil {
+			return nil, err
+		}
+	}
+
+	amounts, err := d.QuoteOut(amountIn, path)
+	if err != nil {
+		return nil, err
+	}
+	minOut := MinAmountOut(amounts[len(amounts)-1], opts.Slippage)
+	deadline := big.NewInt(time.Now().Add(opts.Deadline).Unix())
+
+	if trxIn {
+		if !amountIn.IsInt64() {
+			return nil, fmt.Errorf("invalid TRX amount %s", amountIn)
+		}
+		return d.Router.TransactWithValue(ks, amountIn.Int64(), nil, "swapExactETHForTokens", minOut, tokens, opts.Recipient, deadline)
+	}
+
+	method := "swapExactTokensForTokens"
+	if trxOut {
+		method = "swapExactTokensForETH"
+	}
+
+	return d.Router.Transact(ks, method, amountIn, minOut, tokens, opts.Recipient, deadline)
+}
+
+// SwapExactOut buys amountOut of the last token of path for at most the quote raised by the slippage.
+// path holds token addresses, TRX may be its first or last element.
+// The router is approved for the maximum sold first when its allowance is too low
+func (d *DEX) SwapExactOut(ks keystore.Signer, amountOut *big.Int, path []string, opts *SwapOptions) (*transaction.Transaction, error) {
+	tokens, trxIn, trxOut, err := d.resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if opts, err = withDefaults(opts, ks); err != nil {
+		return nil, err
+	}
+
+	amounts, err := d.QuoteIn(amountOut, path)
+	if err != nil {
+		return nil, err
+	}
+	maxIn := MaxAmountIn(amounts[0], opts.Slippage)
+
+	if !trxIn {
+		if err := d.EnsureAllowance(ks, path[0], maxIn); err != nil {
+			return nil, err
+		}
+
+		// quote again after the approval, without exceeding the approved amount
+		if amounts, err = d.QuoteIn(amountOut, path); err != nil {
+			return nil, err
+		}
+		if requoted := MaxAmountIn(amounts[0], opts.Slippage); requoted.Cmp(maxIn) < 0 {
+			maxIn = requoted
+		}
+	}
+	deadline := big.NewInt(time.Now().Add(opts.Deadline).Unix())
+
+	if trxIn {
+		// the router refunds the TRX not swapped
+		if !maxIn.IsInt64() {
+			return nil, fmt.Errorf("invalid TRX amount %s", maxIn)
+		}
+		return d.Router.TransactWithValue(ks, maxIn.Int64(), nil, "swapETHForExactTokens", amountOut, tokens, opts.Recipient, deadline)
+	}
+
+	method := "swapTokensForExactTokens"
+	if trxOut {
+		method = "swapTokensForExactETH"
+	}
+
+	return d.Router.Transact(ks, method, amountOut, maxIn, tokens, opts.Recipient, deadline)
+}
+
+// EnsureAllowance approves the router for amount of token when its allowance is lower,
+// and waits for the confirmation of the approval
+func (d *DEX) EnsureAllowance(ks keystore.Signer, token string, amount *big.Int) error {
+	t, err := trc20.NewTrc20(d.GrpcClient, token)
+	if err != nil {
+		return err
+	}
+
+	allowance, err := t.GetAllowance(ks.GetAddress().String(), d.Router.Address.String())
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+
+	tx, err := t.Approve(ks, d.Router.Address.String(), amount)
+	if err != nil {
+		return fmt.Errorf("approve: %w", err)
+	}
+
+	timeout := d.ApprovalTimeout
+	if timeout == 0 {
+		timeout = DefaultApprovalTimeout
+	}
+
+	info, err := d.WaitForTransactionInfo(tx.TransactionHash, timeout)
+	if err != nil {
+		return fmt.Errorf("approve: %w", err)
+	}
+	if info.GetResult() != core.TransactionInfo_SUCESS {
+		return fmt.Errorf("approve failed: %s", string(info.GetResMessage()))
+	}
+
+	return nil
+}
+
+// resolvePath returns the tokens of path with TRX replaced by WTRX,
+// and whether TRX is sold or bought
+func (d *DEX) resolvePath(path []string) (tokens []address.Address, trxIn, trxOut bool, err error) {
+	if len(path) < 2 {
+		return nil, false, false, fmt.Errorf("path needs at least 2 tokens, got %d", len(path))
+	}
+
+	tokens = make([]address.Address, 0, len(path))
+	for i, token := range path {
+		if token == TRX {
+			switch i {
+			case 0:
+				trxIn = true
+			case len(path) - 1:
+				trxOut = true
+			default:
+				return nil, false, false, errors.New("TRX may only start or end the path")
+			}
+			tokens = append(tokens, d.WTRX)
+			continue
+		}
+
+		addr, err := address.Parse(token)
+		if err != nil {
+			return nil, false, false, fmt.Errorf("path[%d]: %v", i, err)
+		}
+		tokens = append(tokens, addr)
+	}
+
+	if trxIn && trxOut {
+		return nil, false, false, errors.New("path swaps TRX for TRX")
+	}
+
+	return tokens, trxIn, trxOut, nil
+}
+
+// token returns the address of token, WTRX for TRX
+func (d *DEX) token(token string) string {
+	if token == TRX {
+		return d.WTRX.String()
+	}
+
+	return token
+}
+
+func withDefaults(opts *SwapOptions, ks keystore.Signer) (*SwapOptions, error) {
+	o := SwapOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.Slippage == 0 {
+		o.Slippage = DefaultSlippage
+	}
+	if o.Slippage < 0 || o.Slippage >= 10_000 {
+		return nil, fmt.Errorf("invalid slippage %d basis points", o.Slippage)
+	}
+	if o.Deadline == 0 {
+		o.Deadline = DefaultDeadline
+	}
+	if o.Recipient == "" {
+		o.Recipient = ks.GetAddress().String()
+	}
+
+	return &o, nil
+}
+
+func isZero(addr address.Address) bool {
+	if len(addr) == 0 {
+		return true
+	}
+
+	for _, b := range addr[1:] {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,127 @@
+package dex
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// DefaultFee is the swap fee of the Uniswap V2 pairs in basis points, 0.3%
+const DefaultFee int64 = 30
+
+var (
+	ErrInsufficientAmount    = errors.New("insufficient amount")
+	ErrInsufficientLiquidity = errors.New("insufficient liquidity")
+)
+
+var basisPoints = big.NewInt(10_000)
+
+// Hop is a swap through a pair, In and Out are the reserves of the sold and the bought token
+type Hop struct {
+	In  *big.Int
+	Out *big.Int
+}
+
+// GetAmountOut returns the amount bought for amountIn with the reserves of a pair
+// charging fee basis points, as the getAmountOut function of the router library
+func GetAmountOut(amountIn, reserveIn, reserveOut *big.Int, fee int64) (*big.Int, error) {
+	if err := checkFee(fee); err != nil {
+		return nil, err
+	}
+	if amountIn.Sign() <= 0 {
+		return nil, ErrInsufficientAmount
+	}
+	if reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	amountInWithFee := new(big.Int).Mul(amountIn, big.NewInt(10_000-fee))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Mul(reserveIn, basisPoints)
+	denominator.Add(denominator, amountInWithFee)
+
+	return numerator.Quo(numerator, denominator), nil
+}
+
+// GetAmountIn returns the amount to sell to buy amountOut with the reserves of a pair
+// charging fee basis points, as the getAmountIn function of the router library
+func GetAmountIn(amountOut, reserveIn, reserveOut *big.Int, fee int64) (*big.Int, error) {
+	if err := checkFee(fee); err != nil {
+		return nil, err
+	}
+	if amountOut.Sign() <= 0 {
+		return nil, ErrInsufficientAmount
+	}
+	if reserveIn.Sign() <= 0 || reserveOut.Cmp(amountOut) <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	numerator := new(big.Int).Mul(reserveIn, amountOut)
+	numerator.Mul(numerator, basisPoints)
+	denominator := new(big.Int).Sub(reserveOut, amountOut)
+	denominator.Mul(denominator, big.NewInt(10_000-fee))
+
+	amountIn := numerator.Quo(numerator, denominator)
+	return amountIn.Add(amountIn, big.NewInt(1)), nil
+}
+
+// GetAmountsOut returns the amounts along hops when selling amountIn,
+// the first amount is amountIn and the last one the amount bought
+func GetAmountsOut(amountIn *big.Int, hops []Hop, fee int64) ([]*big.Int, error) {
+	if len(hops) == 0 {
+		return nil, errors.New("empty path")
+	}
+
+	amounts := make([]*big.Int, len(hops)+1)
+	amounts[0] = new(big.Int).Set(amountIn)
+	for i, hop := range hops {
+		out, err := GetAmountOut(amounts[i], hop.In, hop.Out, fee)
+		if err != nil {
+			return nil, fmt.Errorf("hop %d: %w", i, err)
+		}
+		amounts[i+1] = out
+	}
+
+	return amounts, nil
+}
+
+// GetAmountsIn returns the amounts along hops when buying amountOut,
+// the first amount is the amount sold and the last one amountOut
+func GetAmountsIn(amountOut *big.Int, hops []Hop, fee int64) ([]*big.Int, error) {
+	if len(hops) == 0 {
+		return nil, errors.New("empty path")
+	}
+
+	amounts := make([]*big.Int, len(hops)+1)
+	amounts[len(hops)] = new(big.Int).Set(amountOut)
+	for i := len(hops) - 1; i >= 0; i-- {
+		in, err := GetAmountIn(amounts[i+1], hops[i].In, hops[i].Out, fee)
+		if err != nil {
+			return nil, fmt.Errorf("hop %d: %w", i, err)
+		}
+		amounts[i] = in
+	}
+
+	return amounts, nil
+}
+
+// checkFee checks that fee is in [0, 10000) basis points
+func checkFee(fee int64) error {
+	if fee < 0 || fee >= 10_000 {
+		return fmt.Errorf("invalid fee %d basis points", fee)
+	}
+
+	return nil
+}
+
+// MinAmountOut returns amountOut lowered by slippage basis points
+func MinAmountOut(amountOut *big.Int, slippage int64) *big.Int {
+	min := new(big.Int).Mul(amountOut, big.NewInt(10_000-slippage))
+	return min.Quo(min, basisPoints)
+}
+
+// MaxAmountIn returns amountIn raised by slippage basis points
+func MaxAmountIn(amountIn *big.Int, slippage int64) *big.Int {
+	max := new(big.Int).Mul(amountIn, big.NewInt(10_000+slippage))
+	return max.Quo(max, basisPoints)
+}
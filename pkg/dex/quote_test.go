@@ -0,0 +1,67 @@
+package dex
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGetAmounts(t *testing.T) {
+	r0, r1 := big.NewInt(1_000_000_000), big.NewInt(2_000_000_000)
+
+	out, err := GetAmountOut(big.NewInt(1_000_000), r0, r1, DefaultFee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Int64() != 1_992_013 {
+		t.Errorf("GetAmountOut = %s, want 1992013", out)
+	}
+
+	in, err := GetAmountIn(out, r0, r1, DefaultFee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in.Int64() != 1_000_000 {
+		t.Errorf("GetAmountIn = %s, want 1000000", in)
+	}
+
+	free, err := GetAmountOut(big.NewInt(1_000_000), r0, r1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free.Cmp(out) <= 0 {
+		t.Errorf("fee free GetAmountOut = %s, want more than %s", free, out)
+	}
+
+	hops := []Hop{{In: r0, Out: r1}, {In: r1, Out: r0}}
+	amounts, err := GetAmountsOut(big.NewInt(1_000_000), hops, DefaultFee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := GetAmountsIn(amounts[2], hops, DefaultFee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back[0].Cmp(amounts[0]) > 0 {
+		t.Errorf("GetAmountsIn = %v, want at most %s sold", back, amounts[0])
+	}
+}
+
+func TestGetAmountsInvalid(t *testing.T) {
+	r0, r1 := big.NewInt(1_000), big.NewInt(2_000)
+
+	for _, fee := range []int64{-1, 10_000, 20_000} {
+		if _, err := GetAmountOut(big.NewInt(1), r0, r1, fee); err == nil {
+			t.Errorf("GetAmountOut accepted fee %d", fee)
+		}
+		if _, err := GetAmountIn(big.NewInt(1), r0, r1, fee); err == nil {
+			t.Errorf("GetAmountIn accepted fee %d", fee)
+		}
+	}
+
+	if _, err := GetAmountIn(r1, r0, r1, DefaultFee); err == nil {
+		t.Error("GetAmountIn bought the whole reserve")
+	}
+	if _, err := GetAmountOut(big.NewInt(0), r0, r1, DefaultFee); err == nil {
+		t.Error("GetAmountOut sold nothing")
+	}
+}
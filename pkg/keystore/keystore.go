@@ -1,18 +1,46 @@
 package keystore
 
 import (
+	"context"
 	"crypto/ecdsa"
-	"crypto/sha256"
 
 	"github.com/craftto/go-tron/pkg/address"
 	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/craftto/go-tron/pkg/transaction"
 	"github.com/ethereum/go-ethereum/crypto"
-	"google.golang.org/protobuf/proto"
 )
 
+// Signer signs transactions for its address, Keystore and the wrappers around it implement it
+type Signer interface {
+	GetAddress() address.Address
+	SignTx(tx *core.Transaction) (*core.Transaction, error)
+}
+
+// ContextSigner is a Signer passing the caller context down to the auditor
+type ContextSigner interface {
+	Signer
+	SignTxWithContext(ctx context.Context, tx *core.Transaction) (*core.Transaction, error)
+}
+
+// SignTxWithContext signs tx with signer, ctx is passed on when signer is a ContextSigner
+func SignTxWithContext(ctx context.Context, signer Signer, tx *core.Transaction) (*core.Transaction, error) {
+	if cs, ok := signer.(ContextSigner); ok {
+		return cs.SignTxWithContext(ctx, tx)
+	}
+
+	return signer.SignTx(tx)
+}
+
+// Auditor records every transaction signed by a Keystore,
+// an error from Record aborts the signature
+type Auditor interface {
+	Record(ctx context.Context, signer address.Address, tx *core.Transaction) error
+}
+
 type Keystore struct {
 	Address    address.Address
 	privateKey ecdsa.PrivateKey
+	auditor    Auditor
 }
 
 func ImportFromPrivateKey(privateKey string) (*Keystore, error) {
@@ -21,27 +49,48 @@ func ImportFromPrivateKey(privateKey string) (*Keystore, error) {
 		return nil, err
 	}
 
+	return newKeystore(privateKeyECDSA), nil
+}
+
+func newKeystore(privateKey *ecdsa.PrivateKey) *Keystore {
 	return &Keystore{
-		Address:    address.PubkeyToAddress(privateKeyECDSA.PublicKey),
-		privateKey: *privateKeyECDSA,
-	}, nil
+		Address:    address.PubkeyToAddress(privateKey.PublicKey),
+		privateKey: *privateKey,
+	}
+}
+
+// GetAddress returns the address of the key
+func (ks *Keystore) GetAddress() address.Address {
+	return ks.Address
+}
+
+// SetAuditor makes the keystore record every following signature with the auditor a
+func (ks *Keystore) SetAuditor(a Auditor) {
+	ks.auditor = a
 }
 
 func (ks *Keystore) SignTx(tx *core.Transaction) (*core.Transaction, error) {
-	rawData, err := proto.Marshal(tx.GetRawData())
+	return ks.SignTxWithContext(context.Background(), tx)
+}
+
+// SignTxWithContext signs tx and passes ctx, which carries the caller details, to the auditor
+func (ks *Keystore) SignTxWithContext(ctx context.Context, tx *core.Transaction) (*core.Transaction, error) {
+	hash, err := transaction.RawDataHash(tx)
 	if err != nil {
 		return nil, err
 	}
 
-	h256h := sha256.New()
-	h256h.Write(rawData)
-	hash := h256h.Sum(nil)
-
 	signature, err := crypto.Sign(hash, &ks.privateKey)
 	if err != nil {
 		return nil, err
 	}
 
+	if ks.auditor != nil {
+		if err := ks.auditor.Record(ctx, ks.Address, tx); err != nil {
+			return nil, err
+		}
+	}
+
 	tx.Signature = append(tx.Signature, signature)
 
 	return tx, nil
@@ -0,0 +1,280 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// ShareVersion is the version tag of the shares produced by SplitSecret
+	ShareVersion = 1
+
+	sharePrefix = "tsss"
+	shareIDSize = 4
+)
+
+// Share is one part of a secret split with Shamir's secret sharing over GF(256)
+type Share struct {
+	Version   byte
+	ID        []byte
+	Threshold byte
+	Index     byte
+	Data      []byte
+}
+
+// String encodes the share as tsss<version>-<id>-<threshold>-<index>-<data>-<checksum>
+func (s *Share) String() string {
+	return fmt.Sprintf("%s%d-%s-%d-%d-%s-%s",
+		sharePrefix,
+		s.Version,
+		hex.EncodeToString(s.ID),
+		s.Threshold,
+		s.Index,
+		hex.EncodeToString(s.Data),
+		hex.EncodeToString(s.checksum()),
+	)
+}
+
+func (s *Share) checksum() []byte {
+	h := sha256.New()
+	h.Write([]byte{s.Version})
+	h.Write(s.ID)
+	h.Write([]byte{s.Threshold, s.Index})
+	h.Write(s.Data)
+	return h.Sum(nil)[:4]
+}
+
+// ParseShare decodes a share encoded by Share.String and verifies its checksum
+func ParseShare(s string) (*Share, error) {
+	if !strings.HasPrefix(s, sharePrefix) {
+		return nil, fmt.Errorf("invalid share prefix")
+	}
+
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(s), sharePrefix), "-")
+	if len(parts) != 6 {
+		return nil, fmt.Errorf("invalid share format")
+	}
+
+	version, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid share version: %v", err)
+	}
+	if version != ShareVersion {
+		return nil, fmt.Errorf("unsupported share version %d", version)
+	}
+
+	id, err := hex.DecodeString(parts[1])
+	if err != nil || len(id) != shareIDSize {
+		return nil, fmt.Errorf("invalid share id")
+	}
+
+	threshold, err := strconv.ParseUint(parts[2], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid share threshold: %v", err)
+	}
+	if threshold < 2 {
+		return nil, fmt.Errorf("invalid share threshold %d", threshold)
+	}
+
+	index, err := strconv.ParseUint(parts[3], 10, 8)
+	if err != nil || index == 0 {
+		return nil, fmt.Errorf("invalid share index")
+	}
+
+	data, err := hex.DecodeString(parts[4])
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("invalid share data")
+	}
+
+	checksum, err := hex.DecodeString(parts[5])
+	if err != nil {
+		return nil, fmt.Errorf("invalid share checksum")
+	}
+
+	share := &Share{
+		Version:   byte(version),
+		ID:        id,
+		Threshold: byte(threshold),
+		Index:     byte(index),
+		Data:      data,
+	}
+
+	if !bytes.Equal(checksum, share.checksum()) {
+		return nil, fmt.Errorf("share checksum mismatch")
+	}
+
+	return share, nil
+}
+
+// SplitSecret splits secret, such as a private key or the bytes of a mnemonic,
+// into n shares of which any threshold rebuild it
+func SplitSecret(secret []byte, n, threshold int) ([]*Share, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("empty secret")
+	}
+	if threshold < 2 || threshold > n {
+		return nil, fmt.Errorf("threshold must be between 2 and %d", n)
+	}
+	if n > 255 {
+		return nil, fmt.Errorf("cannot split in more than 255 shares")
+	}
+
+	id := make([]byte, shareIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	shares := make([]*Share, n)
+	for i := range shares {
+		shares[i] = &Share{
+			Version:   ShareVersion,
+			ID:        id,
+			Threshold: byte(threshold),
+			Index:     byte(i + 1),
+			Data:      make([]byte, len(secret)),
+		}
+	}
+
+	// one random polynomial per byte, its constant term being the secret byte
+	coefficients := make([]byte, threshold)
+	for i, b := range secret {
+		coefficients[0] = b
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, err
+		}
+
+		for _, share := range shares {
+			share.Data[i] = gfEval(coefficients, share.Index)
+		}
+	}
+
+	for i := range coefficients {
+		coefficients[i] = 0
+	}
+
+	return shares, nil
+}
+
+// CombineSecret rebuilds the secret from at least threshold shares of the same split
+func CombineSecret(shares []*Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("no shares")
+	}
+
+	first := shares[0]
+	if first.Threshold < 2 {
+		return nil, fmt.Errorf("invalid share threshold %d", first.Threshold)
+	}
+	if len(shares) < int(first.Threshold) {
+		return nil, fmt.Errorf("need %d shares, got %d", first.Threshold, len(shares))
+	}
+
+	seen := make(map[byte]bool)
+	for _, s := range shares {
+		if s.Version != ShareVersion {
+			return nil, fmt.Errorf("unsupported share version %d", s.Version)
+		}
+		if !bytes.Equal(s.ID, first.ID) || s.Threshold != first.Threshold || len(s.Data) != len(first.Data) {
+			return nil, fmt.Errorf("shares belong to different secrets")
+		}
+		if s.Index == 0 || seen[s.Index] {
+			return nil, fmt.Errorf("invalid or duplicated share index %d", s.Index)
+		}
+		seen[s.Index] = true
+	}
+
+	shares = shares[:first.Threshold]
+
+	// lagrange interpolation at x = 0
+	secret := make([]byte, len(first.Data))
+	for i, si := range shares {
+		basis := byte(1)
+		for j, sj := range shares {
+			if i == j {
+				continue
+			}
+			basis = gfMul(basis, gfDiv(sj.Index, sj.Index^si.Index))
+		}
+
+		for k := range secret {
+			secret[k] ^= gfMul(si.Data[k], basis)
+		}
+	}
+
+	return secret, nil
+}
+
+// SplitPrivateKey splits the keystore private key into n shares of which any threshold restore it
+func (ks *Keystore) SplitPrivateKey(n, threshold int) ([]*Share, error) {
+	return SplitSecret(crypto.FromECDSA(&ks.privateKey), n, threshold)
+}
+
+// ImportFromShares restores a Keystore split with SplitPrivateKey
+func ImportFromShares(shares []*Share) (*Keystore, error) {
+	secret, err := CombineSecret(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.ToECDSA(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKeystore(privateKey), nil
+}
+
+// GF(256) arithmetic with the AES polynomial x^8 + x^4 + x^3 + x + 1
+var (
+	gfExp [510]byte
+	gfLog [256]byte
+)
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		gfExp[i] = x
+		gfLog[x] = byte(i)
+
+		// multiply by the generator 3
+		hi := x & 0x80
+		x2 := x << 1
+		if hi != 0 {
+			x2 ^= 0x1b
+		}
+		x ^= x2
+	}
+	for i := 255; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+// gfEval evaluates the polynomial with the given coefficients at x
+func gfEval(coefficients []byte, x byte) byte {
+	result := byte(0)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result = gfMul(result, x) ^ coefficients[i]
+	}
+	return result
+}
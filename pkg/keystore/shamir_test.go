@@ -0,0 +1,122 @@
+package keystore
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testKeystore(t *testing.T) *Keystore {
+	t.Helper()
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newKeystore(privateKey)
+}
+
+// parseShares round trips the shares through their string encoding
+func parseShares(t *testing.T, shares []*Share) []*Share {
+	t.Helper()
+
+	parsed := make([]*Share, len(shares))
+	for i, s := range shares {
+		var err error
+		if parsed[i], err = ParseShare(s.String()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return parsed
+}
+
+func TestImportFromSharesSubsets(t *testing.T) {
+	const n, threshold = 5, 3
+
+	ks := testKeystore(t)
+	shares, err := ks.SplitPrivateKey(n, threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares = parseShares(t, shares)
+
+	for mask := 1; mask < 1<<n; mask++ {
+		subset := make([]*Share, 0, n)
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				subset = append(subset, shares[i])
+			}
+		}
+
+		restored, err := ImportFromShares(subset)
+		if len(subset) < threshold {
+			if err == nil {
+				t.Errorf("subset %05b: restored from %d shares", mask, len(subset))
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("subset %05b: %v", mask, err)
+			continue
+		}
+		if !bytes.Equal(restored.Address, ks.Address) {
+			t.Errorf("subset %05b: got address %s, want %s", mask, restored.Address, ks.Address)
+		}
+	}
+}
+
+func TestImportFromSharesInvalid(t *testing.T) {
+	ks := testKeystore(t)
+	shares, err := ks.SplitPrivateKey(3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ks.SplitPrivateKey(3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ImportFromShares([]*Share{shares[0], shares[0]}); err == nil {
+		t.Error("restored from duplicated shares")
+	}
+	if _, err := ImportFromShares([]*Share{shares[0], other[1]}); err == nil {
+		t.Error("restored from shares of different splits")
+	}
+
+	zero := *shares[0]
+	zero.Threshold = 0
+	if _, err := ParseShare(zero.String()); err == nil {
+		t.Error("parsed a share with threshold 0")
+	}
+	if _, err := CombineSecret([]*Share{&zero}); err == nil {
+		t.Error("combined a share with threshold 0")
+	}
+}
+
+func TestParseShareChecksum(t *testing.T) {
+	ks := testKeystore(t)
+	shares, err := ks.SplitPrivateKey(3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := shares[0].String()
+	parts := strings.Split(encoded, "-")
+
+	// flip one nibble of the data
+	data := []byte(parts[4])
+	if data[0] == '0' {
+		data[0] = '1'
+	} else {
+		data[0] = '0'
+	}
+	parts[4] = string(data)
+
+	if _, err := ParseShare(strings.Join(parts, "-")); err == nil {
+		t.Error("parsed a tampered share")
+	}
+}
@@ -0,0 +1,414 @@
+package keystore
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"regexp/syntax"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/shengdoushi/base58"
+)
+
+var (
+	base58Alphabet = base58.BitcoinAlphabet.String()
+
+	// range of the base58check value of a 21 bytes address starting with 0x41
+	vanityMin, vanityMax = vanityRange()
+)
+
+// VanityOptions describes the wanted address, every set pattern must match
+type VanityOptions struct {
+	// Prefix of the base58 address, including the leading T
+	Prefix string
+	// Suffix of the base58 address
+	Suffix string
+	// Pattern is matched against the base58 address, see PatternDifficulty
+	Pattern *regexp.Regexp
+	// Workers defaults to the number of CPUs
+	Workers int
+	// Progress is called every ProgressInterval, one second by default
+	Progress         func(VanityProgress)
+	ProgressInterval time.Duration
+}
+
+// VanityProgress reports the state of a running search
+type VanityProgress struct {
+	Attempts uint64
+	Elapsed  time.Duration
+	// Rate is the number of keys tried per second
+	Rate float64
+	// Difficulty is the expected number of attempts, 0 when unknown
+	Difficulty float64
+}
+
+// VanityDifficulty returns the expected number of attempts to find an
+// address with the given prefix and suffix, or an error when they cannot match
+func VanityDifficulty(prefix, suffix string) (float64, error) {
+	for _, s := range []string{prefix, suffix} {
+		for _, c := range s {
+			if !strings.ContainsRune(base58Alphabet, c) {
+				return 0, fmt.Errorf("invalid base58 character %q", c)
+			}
+		}
+	}
+
+	if len(prefix)+len(suffix) > address.AddressLengthBase58 {
+		return 0, fmt.Errorf("pattern is longer than an address")
+	}
+
+	difficulty, err := prefixDifficulty(prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	// the trailing characters come from the checksum and are uniform
+	return difficulty * math.Pow(58, float64(len(suffix))), nil
+}
+
+// PatternDifficulty estimates the expected number of attempts to find an address
+// matching re. The estimate covers the sequences of literals, character classes
+// and dots, which may be anchored and separated by .* or .+, it is 0 for the
+// other patterns. An error is returned when re cannot match an address
+func PatternDifficulty(re *regexp.Regexp) (float64, error) {
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return 0, err
+	}
+
+	segments, ok := patternSegments(parsed.Simplify())
+	if !ok {
+		return 0, nil
+	}
+
+	length := 0
+	for _, seg := range segments {
+		length += len(seg.chars)
+	}
+	if length > address.AddressLengthBase58 {
+		return 0, fmt.Errorf("pattern %s is longer than an address", re)
+	}
+
+	difficulty := 1.0
+	for _, seg := range segments {
+		d, err := seg.difficulty()
+		if err != nil {
+			return 0, fmt.Errorf("pattern %s: %v", re, err)
+		}
+		difficulty *= d
+	}
+
+	return difficulty, nil
+}
+
+// patternSegment is a sequence of characters matched at consecutive positions,
+// each holding the base58 characters allowed there
+type patternSegment struct {
+	chars []string
+	start bool
+	end   bool
+}
+
+func (seg *patternSegment) difficulty() (float64, error) {
+	for _, c := range seg.chars {
+		if c == "" {
+			return 0, fmt.Errorf("a character matches no base58 character")
+		}
+	}
+
+	probability := func(chars []string) float64 {
+		p := 1.0
+		for _, c := range chars {
+			p *= float64(len(c)) / 58
+		}
+		return p
+	}
+
+	switch {
+	case seg.start && seg.end && len(seg.chars) != address.AddressLengthBase58:
+		return 0, fmt.Errorf("an address has %d characters", address.AddressLengthBase58)
+	case seg.start:
+		// the leading single characters are a prefix, counted exactly
+		n := 0
+		for n < len(seg.chars) && len(seg.chars[n]) == 1 {
+			n++
+		}
+		prefix := strings.Join(seg.chars[:n], "")
+		if n == 0 {
+			if !strings.Contains(seg.chars[0], "T") {
+				return 0, fmt.Errorf("addresses start with T")
+			}
+			prefix, n = "T", 1
+		}
+
+		difficulty, err := prefixDifficulty(prefix)
+		if err != nil {
+			return 0, err
+		}
+		return difficulty / probability(seg.chars[n:]), nil
+	case seg.end:
+		return 1 / probability(seg.chars), nil
+	}
+
+	// a floating segment may match at any position but the first
+	positions := address.AddressLengthBase58 - len(seg.chars)
+	return 1 / math.Min(1, float64(positions)*probability(seg.chars)), nil
+}
+
+// patternSegments splits re into segments, ok is false for unsupported patterns
+func patternSegments(re *syntax.Regexp) (segments []*patternSegment, ok bool) {
+	nodes := []*syntax.Regexp{re}
+	if re.Op == syntax.OpConcat {
+		nodes = re.Sub
+	}
+
+	seg := new(patternSegment)
+	for i, node := range nodes {
+		switch {
+		case node.Op == syntax.OpBeginText && i == 0:
+			seg.start = true
+		case node.Op == syntax.OpEndText && i == len(nodes)-1:
+			seg.end = true
+		case (node.Op == syntax.OpStar || node.Op == syntax.OpPlus) && isAnyChar(node.Sub[0]):
+			if node.Op == syntax.OpPlus {
+				seg.chars = append(seg.chars, base58Alphabet)
+			}
+			segments = append(segments, seg)
+			seg = new(patternSegment)
+		default:
+			chars, ok := patternChars(node)
+			if !ok {
+				return nil, false
+			}
+			seg.chars = append(seg.chars, chars...)
+		}
+	}
+	segments = append(segments, seg)
+
+	// a segment without characters matches everywhere, its anchors do not matter
+	nonEmpty := segments[:0]
+	for _, seg := range segments {
+		if len(seg.chars) > 0 {
+			nonEmpty = append(nonEmpty, seg)
+		}
+	}
+
+	return nonEmpty, true
+}
+
+// patternChars returns the base58 characters allowed at each position matched by re
+func patternChars(re *syntax.Regexp) ([]string, bool) {
+	allowed := func(match func(c rune) bool) string {
+		var b strings.Builder
+		for _, c := range base58Alphabet {
+			if match(c) {
+				b.WriteRune(c)
+			}
+		}
+		return b.String()
+	}
+
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return nil, true
+	case syntax.OpLiteral:
+		chars := make([]string, 0, len(re.Rune))
+		for _, r := range re.Rune {
+			r := r
+			chars = append(chars, allowed(func(c rune) bool {
+				return c == r || re.Flags&syntax.FoldCase != 0 && strings.EqualFold(string(c), string(r))
+			}))
+		}
+		return chars, true
+	case syntax.OpCharClass:
+		return []string{allowed(func(c rune) bool {
+			for i := 0; i+1 < len(re.Rune); i += 2 {
+				if re.Rune[i] <= c && c <= re.Rune[i+1] {
+					return true
+				}
+			}
+			return false
+		})}, true
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return []string{base58Alphabet}, true
+	case syntax.OpCapture:
+		return patternChars(re.Sub[0])
+	case syntax.OpConcat:
+		chars := make([]string, 0, len(re.Sub))
+		for _, sub := range re.Sub {
+			subChars, ok := patternChars(sub)
+			if !ok {
+				return nil, false
+			}
+			chars = append(chars, subChars...)
+		}
+		return chars, true
+	}
+
+	return nil, false
+}
+
+func isAnyChar(re *syntax.Regexp) bool {
+	return re.Op == syntax.OpAnyChar || re.Op == syntax.OpAnyCharNotNL
+}
+
+// prefixDifficulty returns the expected number of attempts to find an address starting with prefix
+func prefixDifficulty(prefix string) (float64, error) {
+	if prefix == "" {
+		return 1, nil
+	}
+
+	// count the addresses inside [prefix00..0, prefixzz..z]
+	width := new(big.Int).Exp(big.NewInt(58), big.NewInt(int64(address.AddressLengthBase58-len(prefix))), nil)
+	low := new(big.Int)
+	for _, c := range prefix {
+		low.Mul(low, big.NewInt(58))
+		low.Add(low, big.NewInt(int64(strings.IndexRune(base58Alphabet, c))))
+	}
+	low.Mul(low, width)
+	high := new(big.Int).Add(low, width)
+	high.Sub(high, big.NewInt(1))
+
+	if low.Cmp(vanityMin) < 0 {
+		low = vanityMin
+	}
+	if high.Cmp(vanityMax) > 0 {
+		high = vanityMax
+	}
+	if low.Cmp(high) > 0 {
+		return 0, fmt.Errorf("no address starts with %s", prefix)
+	}
+
+	matching := new(big.Int).Sub(high, low)
+	matching.Add(matching, big.NewInt(1))
+	total := new(big.Int).Sub(vanityMax, vanityMin)
+	total.Add(total, big.NewInt(1))
+
+	difficulty, _ := new(big.Float).Quo(new(big.Float).SetInt(total), new(big.Float).SetInt(matching)).Float64()
+	return difficulty, nil
+}
+
+// GenerateVanity searches keys in parallel until the base58 address matches
+// opts, and stops with the context error when ctx is done
+func GenerateVanity(ctx context.Context, opts VanityOptions) (*Keystore, error) {
+	if opts.Prefix == "" && opts.Suffix == "" && opts.Pattern == nil {
+		return nil, fmt.Errorf("no pattern")
+	}
+
+	difficulty, err := VanityDifficulty(opts.Prefix, opts.Suffix)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Pattern != nil {
+		patternDifficulty, err := PatternDifficulty(opts.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		// the pattern is assumed independent of the prefix and the suffix
+		difficulty *= patternDifficulty
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	interval := opts.ProgressInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		attempts uint64
+		found    = make(chan *ecdsa.PrivateKey, 1)
+		errs     = make(chan error, workers)
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				key, err := crypto.GenerateKey()
+				if err != nil {
+					errs <- err
+					return
+				}
+				atomic.AddUint64(&attempts, 1)
+
+				addr := address.PubkeyToAddress(key.PublicKey).Base58()
+				if strings.HasPrefix(addr, opts.Prefix) &&
+					strings.HasSuffix(addr, opts.Suffix) &&
+					(opts.Pattern == nil || opts.Pattern.MatchString(addr)) {
+					select {
+					case found <- key:
+					default:
+					}
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if opts.Progress != nil {
+				elapsed := time.Since(start)
+				n := atomic.LoadUint64(&attempts)
+				opts.Progress(VanityProgress{
+					Attempts:   n,
+					Elapsed:    elapsed,
+					Rate:       float64(n) / elapsed.Seconds(),
+					Difficulty: difficulty,
+				})
+			}
+		case err := <-errs:
+			cancel()
+			<-done
+			return nil, err
+		case <-done:
+			select {
+			case key := <-found:
+				return newKeystore(key), nil
+			default:
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
+
+func vanityRange() (*big.Int, *big.Int) {
+	shift := new(big.Int).Lsh(big.NewInt(1), 8*(address.AddressLength-1+4))
+
+	min := new(big.Int).Mul(big.NewInt(int64(address.TronBytePrefix)), shift)
+	max := new(big.Int).Mul(big.NewInt(int64(address.TronBytePrefix)+1), shift)
+	max.Sub(max, big.NewInt(1))
+
+	return min, max
+}
@@ -0,0 +1,174 @@
+package keystore
+
+import (
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVanityDifficulty(t *testing.T) {
+	tx, err := VanityDifficulty("TX", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		prefix, suffix string
+		want           float64
+	}{
+		{"", "", 1},
+		{"T", "", 1},
+		{"", "A", 58},
+		{"", "xyz", 58 * 58 * 58},
+		{"TX", "", tx},
+		{"TX", "A", tx * 58},
+	}
+
+	for _, test := range tests {
+		got, err := VanityDifficulty(test.prefix, test.suffix)
+		if err != nil {
+			t.Errorf("VanityDifficulty(%q, %q) = %v", test.prefix, test.suffix, err)
+			continue
+		}
+		if !closeTo(got, test.want) {
+			t.Errorf("VanityDifficulty(%q, %q) = %g, want %g", test.prefix, test.suffix, got, test.want)
+		}
+	}
+
+	// only a part of the addresses start with TX, at least one in 58^2
+	if tx <= 1 || tx > math.Pow(58, 2) {
+		t.Errorf("VanityDifficulty(TX) = %g", tx)
+	}
+
+	for _, invalid := range [][2]string{
+		{"T0", ""},
+		{"TO", ""},
+		{"", "l"},
+		{"A", ""},
+		{"T", strings.Repeat("z", 34)},
+	} {
+		if _, err := VanityDifficulty(invalid[0], invalid[1]); err == nil {
+			t.Errorf("VanityDifficulty(%q, %q) accepted an impossible pattern", invalid[0], invalid[1])
+		}
+	}
+}
+
+func TestPatternDifficulty(t *testing.T) {
+	tx, err := VanityDifficulty("TX", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		pattern string
+		want    float64
+	}{
+		{"^T", 1},
+		{"^[TX]", 1},
+		{"^TX", tx},
+		{"XYZ$", 58 * 58 * 58},
+		{"(?i)xyz$", 29 * 29 * 29},
+		{"[a-k]$", 58.0 / 11},
+		{"^TX.*XYZ$", tx * 58 * 58 * 58},
+		{"^TX.+XYZ$", tx * 58 * 58 * 58},
+		{"(XYZ)$", 58 * 58 * 58},
+		{"XYZ", 58 * 58 * 58 / 31.0},
+		{".", 1},
+		{"(A|B)$", 29},
+		// too complex to estimate
+		{"^T(AB|C)", 0},
+		{"X+$", 0},
+	}
+
+	for _, test := range tests {
+		got, err := PatternDifficulty(regexp.MustCompile(test.pattern))
+		if err != nil {
+			t.Errorf("PatternDifficulty(%s) = %v", test.pattern, err)
+			continue
+		}
+		if !closeTo(got, test.want) {
+			t.Errorf("PatternDifficulty(%s) = %g, want %g", test.pattern, got, test.want)
+		}
+	}
+
+	for _, invalid := range []string{
+		"0$",
+		"[0OIl]",
+		"^A",
+		"^[AB]",
+		"^TXYZ$",
+		"^T.{40}",
+	} {
+		if _, err := PatternDifficulty(regexp.MustCompile(invalid)); err == nil {
+			t.Errorf("PatternDifficulty(%s) accepted an impossible pattern", invalid)
+		}
+	}
+}
+
+func TestGenerateVanity(t *testing.T) {
+	tests := []VanityOptions{
+		{Suffix: "A"},
+		{Prefix: "T", Suffix: "z"},
+		{Pattern: regexp.MustCompile("[a-k]$")},
+		{Pattern: regexp.MustCompile("^T.*[1-3]$"), Workers: 1},
+	}
+
+	for _, opts := range tests {
+		ks, err := GenerateVanity(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("GenerateVanity(%+v) = %v", opts, err)
+		}
+
+		addr := ks.Address.String()
+		if !strings.HasPrefix(addr, opts.Prefix) || !strings.HasSuffix(addr, opts.Suffix) ||
+			opts.Pattern != nil && !opts.Pattern.MatchString(addr) {
+			t.Errorf("GenerateVanity(%+v) = %s", opts, addr)
+		}
+	}
+}
+
+func TestGenerateVanityInvalid(t *testing.T) {
+	for _, opts := range []VanityOptions{
+		{},
+		{Prefix: "A"},
+		{Suffix: "0"},
+		{Pattern: regexp.MustCompile("^A")},
+	} {
+		if _, err := GenerateVanity(context.Background(), opts); err == nil {
+			t.Errorf("GenerateVanity(%+v) accepted an impossible pattern", opts)
+		}
+	}
+}
+
+func TestGenerateVanityCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var progress atomic.Value
+	_, err := GenerateVanity(ctx, VanityOptions{
+		Suffix:           "zzzzzzzz",
+		Workers:          2,
+		ProgressInterval: 10 * time.Millisecond,
+		Progress:         func(p VanityProgress) { progress.Store(p) },
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GenerateVanity() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	p, ok := progress.Load().(VanityProgress)
+	if !ok {
+		t.Fatal("no progress reported")
+	}
+	if p.Attempts == 0 || !closeTo(p.Difficulty, math.Pow(58, 8)) {
+		t.Errorf("progress = %+v", p)
+	}
+}
+
+func closeTo(got, want float64) bool {
+	return math.Abs(got-want) <= 1e-9*math.Abs(want)
+}
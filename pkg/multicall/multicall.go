@@ -0,0 +1,215 @@
+package multicall
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const (
+	// DefaultBatchSize is the number of calls aggregated in one constant call
+	DefaultBatchSize = 100
+	// DefaultConcurrency is the number of concurrent calls without multicall contract
+	DefaultConcurrency = 8
+)
+
+// Multicall2ABI is the tryAggregate method of the Multicall2 contract
+const Multicall2ABI = `[{"inputs":[{"internalType":"bool","name":"requireSuccess","type":"bool"},{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall2.Call[]","name":"calls","type":"tuple[]"}],"name":"tryAggregate","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall2.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"nonpayable","type":"function"}]`
+
+var tryAggregate = mustTryAggregate()
+
+// Call is a constant call of Data on the Target contract
+type Call struct {
+	Target address.Address
+	Data   []byte
+}
+
+// Result is the result of a Call
+type Result struct {
+	Success    bool
+	ReturnData []byte
+	// Err is the error of a failed call, an *abi.RevertError for reverts
+	Err error
+}
+
+// Multicall runs batches of constant calls
+type Multicall struct {
+	// Address is the Multicall2 contract, calls are run individually when empty
+	Address     address.Address
+	BatchSize   int
+	Concurrency int
+	*client.GrpcClient
+}
+
+// New returns a Multicall using the Multicall2 contract deployed at multicallAddr,
+// or running the calls individually when multicallAddr is empty
+func New(g *client.GrpcClient, multicallAddr string) (*Multicall, error) {
+	mc := &Multicall{
+		BatchSize:   DefaultBatchSize,
+		Concurrency: DefaultConcurrency,
+		GrpcClient:  g,
+	}
+
+	if multicallAddr != "" {
+		addr, err := address.Parse(multicallAddr)
+		if err != nil {
+			return nil, err
+		}
+		mc.Address = addr
+	}
+
+	return mc, nil
+}
+
+// Aggregate runs calls and returns their results in the same order.
+// A failed call does not fail the others, see Result.Err
+func (mc *Multicall) Aggregate(calls []Call) ([]Result, error) {
+	if len(mc.Address) == 0 {
+		return mc.callAll(calls), nil
+	}
+
+	batchSize := mc.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	results := make([]Result, 0, len(calls))
+	for start := 0; start < len(calls); start += batchSize {
+		end := start + batchSize
+		if end > len(calls) {
+			end = len(calls)
+		}
+
+		batch, err := mc.aggregate(calls[start:end])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, batch...)
+	}
+
+	return results, nil
+}
+
+func (mc *Multicall) aggregate(calls []Call) ([]Result, error) {
+	values := make([]interface{}, 0, len(calls))
+	for _, c := range calls {
+		values = append(values, []interface{}{c.Target, c.Data})
+	}
+
+	args, err := abi.ConvertArguments(tryAggregate.Inputs, []interface{}{false, values})
+	if err != nil {
+		return nil, err
+	}
+
+	packed, err := tryAggregate.Inputs.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, len(tryAggregate.ID)+len(packed))
+	data = append(append(data, tryAggregate.ID...), packed...)
+
+	result, err := mc.callConstant(mc.Address, data)
+	if err != nil {
+		return nil, fmt.Errorf("tryAggregate: %w", err)
+	}
+
+	outputs, err := tryAggregate.Outputs.Unpack(result)
+	if err != nil {
+		return nil, fmt.Errorf("tryAggregate: %v", err)
+	}
+
+	rv := reflect.ValueOf(outputs[0])
+	if rv.Kind() != reflect.Slice || rv.Len() != len(calls) {
+		return nil, fmt.Errorf("tryAggregate: expected %d results", len(calls))
+	}
+
+	results := make([]Result, 0, len(calls))
+	for i := 0; i < rv.Len(); i++ {
+		r := Result{
+			Success:    rv.Index(i).Field(0).Bool(),
+			ReturnData: rv.Index(i).Field(1).Bytes(),
+		}
+		if !r.Success {
+			r.Err = abi.DecodeRevert(nil, r.ReturnData)
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+// callAll runs the calls individually with at most Concurrency calls in flight
+func (mc *Multicall) callAll(calls []Call) []Result {
+	concurrency := mc.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	results := make([]Result, len(calls))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, c := range calls {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, c Call) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			data, err := mc.callConstant(c.Target, c.Data)
+			if err != nil {
+				results[i] = Result{Err: err}
+
+				var revert *abi.RevertError
+				if errors.As(err, &revert) {
+					results[i].ReturnData = revert.Data
+				}
+				return
+			}
+
+			results[i] = Result{Success: true, ReturnData: data}
+		}(i, c)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (mc *Multicall) callConstant(target address.Address, data []byte) ([]byte, error) {
+	owner, _ := address.Hex2Address(address.ZeroAddress)
+	ct := &core.TriggerSmartContract{
+		OwnerAddress:    owner.Bytes(),
+		ContractAddress: target.Bytes(),
+		Data:            data,
+	}
+
+	result, err := mc.TriggerConstantSmartContract(ct)
+	if err != nil {
+		return nil, err
+	}
+
+	constantResult := result.GetConstantResult()
+	if len(constantResult) == 0 {
+		return nil, errors.New("empty constant result")
+	}
+
+	return constantResult[0], nil
+}
+
+func mustTryAggregate() ethabi.Method {
+	a, err := abi.ParseJSON(Multicall2ABI)
+	if err != nil {
+		panic(err)
+	}
+
+	return a.Methods["tryAggregate"]
+}
@@ -0,0 +1,226 @@
+package multicall
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/proto/api"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+)
+
+var (
+	multicallAddr = testAddress(0xaa)
+	revertReason  = "call reverted"
+)
+
+func testAddress(b byte) address.Address {
+	addr := make(address.Address, 21)
+	addr[0] = address.TronBytePrefix
+	addr[20] = b
+	return addr
+}
+
+// stubNode answers the constant calls of the contracts in the tests:
+// the Multicall2 contract at multicallAddr runs tryAggregate and the
+// other contracts return their address followed by the call data,
+// or revert when the call data is "revert"
+type stubNode struct {
+	api.WalletClient
+
+	mu      sync.Mutex
+	batches []int
+	calls   int
+	// drop is the number of results removed from the tryAggregate results
+	drop int
+}
+
+func (n *stubNode) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	if !bytes.Equal(in.ContractAddress, multicallAddr) {
+		n.mu.Lock()
+		n.calls++
+		n.mu.Unlock()
+
+		ret, ok := n.call(in.ContractAddress, in.Data)
+		if !ok {
+			return &api.TransactionExtention{
+				Result:         &api.Return{Code: api.Return_CONTRACT_EXE_ERROR},
+				ConstantResult: [][]byte{ret},
+				Transaction: &core.Transaction{
+					Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_REVERT}},
+				},
+			}, nil
+		}
+		return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{ret}}, nil
+	}
+
+	if !bytes.Equal(in.Data[:4], tryAggregate.ID) {
+		return nil, errors.New("unknown method")
+	}
+	inputs, err := tryAggregate.Inputs.Unpack(in.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		Success    bool
+		ReturnData []byte
+	}
+
+	calls := reflect.ValueOf(inputs[1])
+	results := make([]result, 0, calls.Len())
+	for i := 0; i < calls.Len(); i++ {
+		target := calls.Index(i).Field(0).Interface().(ethcommon.Address)
+		data := calls.Index(i).Field(1).Bytes()
+
+		ret, ok := n.call(append([]byte{address.TronBytePrefix}, target[:]...), data)
+		results = append(results, result{Success: ok, ReturnData: ret})
+	}
+
+	n.mu.Lock()
+	n.batches = append(n.batches, calls.Len())
+	n.mu.Unlock()
+
+	packed, err := tryAggregate.Outputs.Pack(results[:len(results)-n.drop])
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{packed}}, nil
+}
+
+func (n *stubNode) call(target, data []byte) ([]byte, bool) {
+	if string(data) == "revert" {
+		args := ethabi.Arguments{{Type: mustType("string")}}
+		packed, _ := args.Pack(revertReason)
+		return append(abi.MethodSignature("Error(string)"), packed...), false
+	}
+
+	return append(append([]byte{}, target...), data...), true
+}
+
+func mustType(t string) ethabi.Type {
+	ty, err := abi.NewType(t)
+	if err != nil {
+		panic(err)
+	}
+	return ty
+}
+
+func newCalls(n int) []Call {
+	calls := make([]Call, 0, n)
+	for i := 0; i < n; i++ {
+		data := make([]byte, 4)
+		binary.BigEndian.PutUint32(data, uint32(i))
+		if i%3 == 2 {
+			data = []byte("revert")
+		}
+		calls = append(calls, Call{Target: testAddress(byte(i)), Data: data})
+	}
+	return calls
+}
+
+func newMulticall(node *stubNode, addr address.Address) *Multicall {
+	return &Multicall{
+		Address:     addr,
+		BatchSize:   4,
+		Concurrency: 2,
+		GrpcClient:  &client.GrpcClient{Client: node},
+	}
+}
+
+func checkResults(t *testing.T, calls []Call, results []Result) {
+	t.Helper()
+
+	if len(results) != len(calls) {
+		t.Fatalf("got %d results, want %d", len(results), len(calls))
+	}
+
+	for i, r := range results {
+		if string(calls[i].Data) == "revert" {
+			var revert *abi.RevertError
+			if r.Success || !errors.As(r.Err, &revert) || revert.Reason != revertReason {
+				t.Errorf("results[%d] = %+v, want a revert", i, r)
+			}
+			if len(r.ReturnData) == 0 {
+				t.Errorf("results[%d].ReturnData is empty, want the revert data", i)
+			}
+			continue
+		}
+
+		want := append(append([]byte{}, calls[i].Target...), calls[i].Data...)
+		if !r.Success || r.Err != nil || !bytes.Equal(r.ReturnData, want) {
+			t.Errorf("results[%d] = %+v, want %x", i, r, want)
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	node := &stubNode{}
+	calls := newCalls(10)
+
+	results, err := newMulticall(node, multicallAddr).Aggregate(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkResults(t, calls, results)
+
+	if !reflect.DeepEqual(node.batches, []int{4, 4, 2}) {
+		t.Errorf("batches = %v, want [4 4 2]", node.batches)
+	}
+	if node.calls != 0 {
+		t.Errorf("%d individual calls, want none", node.calls)
+	}
+}
+
+func TestAggregateDefaultBatchSize(t *testing.T) {
+	node := &stubNode{}
+	mc := newMulticall(node, multicallAddr)
+	mc.BatchSize = 0
+
+	calls := newCalls(DefaultBatchSize + 1)
+	results, err := mc.Aggregate(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkResults(t, calls, results)
+
+	if !reflect.DeepEqual(node.batches, []int{DefaultBatchSize, 1}) {
+		t.Errorf("batches = %v", node.batches)
+	}
+}
+
+func TestAggregateResultCount(t *testing.T) {
+	node := &stubNode{drop: 1}
+
+	_, err := newMulticall(node, multicallAddr).Aggregate(newCalls(3))
+	if err == nil || !strings.Contains(err.Error(), "expected 3 results") {
+		t.Errorf("Aggregate() = %v, want a result count error", err)
+	}
+}
+
+func TestAggregateWithoutContract(t *testing.T) {
+	node := &stubNode{}
+	calls := newCalls(10)
+
+	results, err := newMulticall(node, nil).Aggregate(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkResults(t, calls, results)
+
+	if node.calls != len(calls) || len(node.batches) != 0 {
+		t.Errorf("%d individual calls and %d batches, want %d calls", node.calls, len(node.batches), len(calls))
+	}
+}
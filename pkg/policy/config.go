@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Config is the declarative set of rules checked before a transaction is signed.
+// Empty rules allow everything.
+//
+//	{
+//	  "allowedContractTypes": ["TransferContract", "TriggerSmartContract"],
+//	  "destinationAllowlist": ["TJCnKsPa7y5okkXvQAidZBzqx3QyQ6sxMW"],
+//	  "trx": {"perTransaction": "1000000000", "daily": "10000000000"},
+//	  "trc10": {"1002000": {"daily": "500000000"}},
+//	  "trc20": {
+//	    "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t": {"perTransaction": "5000000000", "daily": "20000000000"}
+//	  },
+//	  "allowedMethods": {
+//	    "*": ["transfer(address,uint256)"],
+//	    "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t": ["approve(address,uint256)"]
+//	  }
+//	}
+type Config struct {
+	// AllowedContractTypes lists contract type names such as "TransferContract"
+	AllowedContractTypes []string `json:"allowedContractTypes"`
+	// DestinationAllowlist lists the base58 addresses allowed to receive value.
+	// It applies to TRX and TRC10 recipients, TRC20 recipients and spenders
+	// and to the contracts of any other smart contract call
+	DestinationAllowlist []string `json:"destinationAllowlist"`
+	// TRX limits transfers and call values, in SUN
+	TRX *LimitConfig `json:"trx"`
+	// TRC10 limits transfers and call token values by token id, in token base units
+	TRC10 map[string]*LimitConfig `json:"trc10"`
+	// TRC20 limits token transfers by base58 token contract address, in token base units
+	TRC20 map[string]*LimitConfig `json:"trc20"`
+	// AllowedMethods lists method signatures or 4 bytes hex selectors callable
+	// by base58 contract address, "*" applies to every contract
+	AllowedMethods map[string][]string `json:"allowedMethods"`
+}
+
+// LimitConfig holds integer amounts as strings, an empty string is no limit
+type LimitConfig struct {
+	PerTransaction string `json:"perTransaction"`
+	Daily          string `json:"daily"`
+}
+
+// LoadConfig reads a JSON policy file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := new(Config)
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Load reads a JSON policy file and builds the Policy
+func Load(path string) (*Policy, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(cfg)
+}
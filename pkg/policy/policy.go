@@ -0,0 +1,345 @@
+package policy
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/common"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/craftto/go-tron/pkg/transaction"
+)
+
+const (
+	anyContract = "*"
+	trxKey      = "TRX"
+	// trc10Prefix keys the daily totals of the TRC10 tokens by id
+	trc10Prefix = "TRC10:"
+)
+
+// RejectionError is returned when a transaction violates a policy rule
+type RejectionError struct {
+	Rule   string
+	Reason string
+}
+
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("policy %s rejected transaction: %s", e.Rule, e.Reason)
+}
+
+type limit struct {
+	perTransaction *big.Int
+	daily          *big.Int
+}
+
+type spend struct {
+	key    string
+	day    string
+	amount *big.Int
+}
+
+// Policy approves or rejects transactions and tracks the amounts spent per UTC day
+type Policy struct {
+	contractTypes map[core.Transaction_Contract_ContractType]bool
+	destinations  map[string]bool
+	trx           *limit
+	trc10         map[string]*limit
+	trc20         map[string]*limit
+	methods       map[string]map[string]bool
+
+	mu    sync.Mutex
+	day   string
+	spent map[string]*big.Int
+
+	// Now returns the current time, used for the daily limits
+	Now func() time.Time
+}
+
+// New builds a Policy from cfg
+func New(cfg *Config) (*Policy, error) {
+	p := &Policy{
+		contractTypes: make(map[core.Transaction_Contract_ContractType]bool),
+		destinations:  make(map[string]bool),
+		trc10:         make(map[string]*limit),
+		trc20:         make(map[string]*limit),
+		methods:       make(map[string]map[string]bool),
+		spent:         make(map[string]*big.Int),
+		Now:           time.Now,
+	}
+
+	for _, name := range cfg.AllowedContractTypes {
+		t, ok := core.Transaction_Contract_ContractType_value[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid contract type %s", name)
+		}
+		p.contractTypes[core.Transaction_Contract_ContractType(t)] = true
+	}
+
+	for _, dest := range cfg.DestinationAllowlist {
+		addr, err := address.Base58ToAddress(dest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination %s: %v", dest, err)
+		}
+		p.destinations[addr.Hex()] = true
+	}
+
+	var err error
+	if p.trx, err = parseLimit(cfg.TRX); err != nil {
+		return nil, fmt.Errorf("invalid trx limit: %v", err)
+	}
+
+	for id, l := range cfg.TRC10 {
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid trc10 id %s", id)
+		}
+		if p.trc10[id], err = parseLimit(l); err != nil {
+			return nil, fmt.Errorf("invalid trc10 %s limit: %v", id, err)
+		}
+	}
+
+	for token, l := range cfg.TRC20 {
+		addr, err := address.Base58ToAddress(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trc20 %s: %v", token, err)
+		}
+		if p.trc20[addr.Hex()], err = parseLimit(l); err != nil {
+			return nil, fmt.Errorf("invalid trc20 %s limit: %v", token, err)
+		}
+	}
+
+	for contract, methods := range cfg.AllowedMethods {
+		key := anyContract
+		if contract != anyContract {
+			addr, err := address.Base58ToAddress(contract)
+			if err != nil {
+				return nil, fmt.Errorf("invalid contract %s: %v", contract, err)
+			}
+			key = addr.Hex()
+		}
+
+		selectors := make(map[string]bool)
+		for _, method := range methods {
+			selector, err := parseSelector(method)
+			if err != nil {
+				return nil, err
+			}
+			selectors[selector] = true
+		}
+		p.methods[key] = selectors
+	}
+
+	return p, nil
+}
+
+// Check validates tx against the rules without recording its amounts
+func (p *Policy) Check(tx *core.Transaction) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err := p.check(tx)
+	return err
+}
+
+// Approve validates tx against the rules and records its amounts in the daily totals
+func (p *Policy) Approve(tx *core.Transaction) error {
+	_, err := p.approve(tx)
+	return err
+}
+
+func (p *Policy) approve(tx *core.Transaction) ([]spend, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	spends, err := p.check(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	day := p.today()
+	for i, s := range spends {
+		spends[i].day = day
+		p.spent[s.key] = new(big.Int).Add(p.spentToday(s.key), s.amount)
+	}
+
+	return spends, nil
+}
+
+// release removes amounts recorded by approve, when signing failed afterwards.
+// The amounts recorded on a previous day are already out of the daily totals
+func (p *Policy) release(spends []spend) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	day := p.today()
+	for _, s := range spends {
+		if s.day != day {
+			continue
+		}
+		p.spent[s.key] = new(big.Int).Sub(p.spentToday(s.key), s.amount)
+	}
+}
+
+func (p *Policy) check(tx *core.Transaction) ([]spend, error) {
+	summary, err := transaction.DecodeContract(tx)
+	if err != nil {
+		return nil, &RejectionError{Rule: "decode", Reason: err.Error()}
+	}
+
+	if len(p.contractTypes) > 0 && !p.contractTypes[summary.Type] {
+		return nil, &RejectionError{
+			Rule:   "contract type",
+			Reason: fmt.Sprintf("%s is not allowed", summary.Type),
+		}
+	}
+
+	if recipient := summary.Recipient(); len(p.destinations) > 0 && recipient != nil {
+		if !p.destinations[recipient.Hex()] {
+			return nil, &RejectionError{
+				Rule:   "destination",
+				Reason: fmt.Sprintf("%s is not in the allowlist", recipient),
+			}
+		}
+	}
+
+	if summary.Type == core.Transaction_Contract_TriggerSmartContract && len(p.methods) > 0 {
+		selector := hex.EncodeToString(summary.Method)
+		if !p.methods[anyContract][selector] && !p.methods[summary.To.Hex()][selector] {
+			return nil, &RejectionError{
+				Rule:   "method",
+				Reason: fmt.Sprintf("method 0x%s is not allowed on %s", selector, summary.To),
+			}
+		}
+	}
+
+	spends := make([]spend, 0)
+
+	trxAmount, trc10Amount := summary.Amount, summary.TokenValue
+	if summary.Type == core.Transaction_Contract_TransferAssetContract {
+		trxAmount, trc10Amount = 0, summary.Amount
+	}
+
+	if trxAmount > 0 {
+		amount := big.NewInt(trxAmount)
+		if err := p.checkLimit(trxKey, trxKey, p.trx, amount, true); err != nil {
+			return nil, err
+		}
+		spends = append(spends, spend{key: trxKey, amount: amount})
+	}
+
+	if trc10Amount > 0 {
+		key := trc10Prefix + summary.AssetID
+		amount := big.NewInt(trc10Amount)
+		if err := p.checkLimit(key, "TRC10 "+summary.AssetID, p.trc10[summary.AssetID], amount, true); err != nil {
+			return nil, err
+		}
+		spends = append(spends, spend{key: key, amount: amount})
+	}
+
+	if summary.Token != nil {
+		key := summary.To.Hex()
+		// approvals are bounded by the per transaction limit but are not spent
+		daily := summary.Token.Method != "approve"
+		if err := p.checkLimit(key, summary.To.String(), p.trc20[key], summary.Token.Amount, daily); err != nil {
+			return nil, err
+		}
+		if daily {
+			spends = append(spends, spend{key: key, amount: summary.Token.Amount})
+		}
+	}
+
+	return spends, nil
+}
+
+// checkLimit checks amount against l and the daily total of key, name is the token in the rejection
+func (p *Policy) checkLimit(key, name string, l *limit, amount *big.Int, daily bool) error {
+	if l == nil {
+		return nil
+	}
+
+	if l.perTransaction != nil && amount.Cmp(l.perTransaction) > 0 {
+		return &RejectionError{
+			Rule:   "per transaction limit",
+			Reason: fmt.Sprintf("%s amount %s exceeds %s", name, amount, l.perTransaction),
+		}
+	}
+
+	if daily && l.daily != nil {
+		total := new(big.Int).Add(p.spentToday(key), amount)
+		if total.Cmp(l.daily) > 0 {
+			return &RejectionError{
+				Rule:   "daily limit",
+				Reason: fmt.Sprintf("%s daily total %s exceeds %s", name, total, l.daily),
+			}
+		}
+	}
+
+	return nil
+}
+
+// today resets the daily totals when the UTC day changed and returns the day,
+// it must be called with p.mu held
+func (p *Policy) today() string {
+	day := p.Now().UTC().Format("2006-01-02")
+	if day != p.day {
+		p.day = day
+		p.spent = make(map[string]*big.Int)
+	}
+
+	return day
+}
+
+// spentToday must be called with p.mu held
+func (p *Policy) spentToday(key string) *big.Int {
+	p.today()
+
+	if spent, ok := p.spent[key]; ok {
+		return spent
+	}
+
+	return new(big.Int)
+}
+
+func parseLimit(cfg *LimitConfig) (*limit, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	l := new(limit)
+	for _, v := range []struct {
+		value string
+		dst   **big.Int
+	}{
+		{cfg.PerTransaction, &l.perTransaction},
+		{cfg.Daily, &l.daily},
+	} {
+		if v.value == "" {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(v.value, 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("invalid amount %s", v.value)
+		}
+		*v.dst = amount
+	}
+
+	return l, nil
+}
+
+func parseSelector(method string) (string, error) {
+	if strings.Contains(method, "(") {
+		return hex.EncodeToString(abi.MethodSignature(method)), nil
+	}
+
+	selector, err := common.Hex2Bytes(method)
+	if err != nil || len(selector) != 4 {
+		return "", fmt.Errorf("invalid method %s", method)
+	}
+
+	return hex.EncodeToString(selector), nil
+}
@@ -0,0 +1,366 @@
+package policy
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+var (
+	owner     = testAddress(1)
+	recipient = testAddress(2)
+	stranger  = testAddress(3)
+	token     = testAddress(4)
+)
+
+func testAddress(b byte) address.Address {
+	addr := make([]byte, address.AddressLength)
+	addr[0] = address.TronBytePrefix
+	for i := 1; i < len(addr); i++ {
+		addr[i] = b
+	}
+
+	return addr
+}
+
+func newTx(t *testing.T, contracts ...proto.Message) *core.Transaction {
+	t.Helper()
+
+	raw := new(core.TransactionRaw)
+	for _, ct := range contracts {
+		value, err := proto.Marshal(ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var typ core.Transaction_Contract_ContractType
+		switch ct.(type) {
+		case *core.TransferContract:
+			typ = core.Transaction_Contract_TransferContract
+		case *core.TransferAssetContract:
+			typ = core.Transaction_Contract_TransferAssetContract
+		case *core.TriggerSmartContract:
+			typ = core.Transaction_Contract_TriggerSmartContract
+		default:
+			t.Fatalf("unexpected contract %T", ct)
+		}
+
+		raw.Contract = append(raw.Contract, &core.Transaction_Contract{
+			Type:      typ,
+			Parameter: &any.Any{Value: value},
+		})
+	}
+
+	return &core.Transaction{RawData: raw}
+}
+
+func transferTRX(to address.Address, amount int64) *core.TransferContract {
+	return &core.TransferContract{OwnerAddress: owner, ToAddress: to, Amount: amount}
+}
+
+func transferTRC10(id string, to address.Address, amount int64) *core.TransferAssetContract {
+	return &core.TransferAssetContract{AssetName: []byte(id), OwnerAddress: owner, ToAddress: to, Amount: amount}
+}
+
+// callToken returns the call of the TRC20 method on contract
+func callToken(contract address.Address, method string, to address.Address, amount int64) *core.TriggerSmartContract {
+	word := make([]byte, 32)
+	copy(word[12:], to[1:])
+
+	data := append(abi.MethodSignature(method), word...)
+	data = append(data, big.NewInt(amount).FillBytes(make([]byte, 32))...)
+
+	return &core.TriggerSmartContract{OwnerAddress: owner, ContractAddress: contract, Data: data}
+}
+
+func newPolicy(t *testing.T, cfg *Config) *Policy {
+	t.Helper()
+
+	p, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Now = func() time.Time { return time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	return p
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		tx   []proto.Message
+		rule string
+	}{
+		{
+			name: "empty config",
+			cfg:  &Config{},
+			tx:   []proto.Message{transferTRX(stranger, 1)},
+		},
+		{
+			name: "contract type allowed",
+			cfg:  &Config{AllowedContractTypes: []string{"TransferContract"}},
+			tx:   []proto.Message{transferTRX(recipient, 1)},
+		},
+		{
+			name: "contract type rejected",
+			cfg:  &Config{AllowedContractTypes: []string{"TransferContract"}},
+			tx:   []proto.Message{transferTRC10("1002000", recipient, 1)},
+			rule: "contract type",
+		},
+		{
+			name: "destination allowed",
+			cfg:  &Config{DestinationAllowlist: []string{recipient.String()}},
+			tx:   []proto.Message{transferTRX(recipient, 1)},
+		},
+		{
+			name: "destination rejected",
+			cfg:  &Config{DestinationAllowlist: []string{recipient.String()}},
+			tx:   []proto.Message{transferTRX(stranger, 1)},
+			rule: "destination",
+		},
+		{
+			name: "trc10 destination rejected",
+			cfg:  &Config{DestinationAllowlist: []string{recipient.String()}},
+			tx:   []proto.Message{transferTRC10("1002000", stranger, 1)},
+			rule: "destination",
+		},
+		{
+			name: "trc20 recipient allowed",
+			cfg:  &Config{DestinationAllowlist: []string{recipient.String()}},
+			tx:   []proto.Message{callToken(token, "transfer(address,uint256)", recipient, 1)},
+		},
+		{
+			name: "trc20 recipient rejected",
+			cfg:  &Config{DestinationAllowlist: []string{recipient.String(), token.String()}},
+			tx:   []proto.Message{callToken(token, "transfer(address,uint256)", stranger, 1)},
+			rule: "destination",
+		},
+		{
+			name: "trc20 spender rejected",
+			cfg:  &Config{DestinationAllowlist: []string{recipient.String()}},
+			tx:   []proto.Message{callToken(token, "approve(address,uint256)", stranger, 1)},
+			rule: "destination",
+		},
+		{
+			name: "multiple contracts rejected",
+			cfg:  &Config{},
+			tx:   []proto.Message{transferTRX(recipient, 1), transferTRX(stranger, 1)},
+			rule: "decode",
+		},
+		{
+			name: "method allowed on any contract",
+			cfg:  &Config{AllowedMethods: map[string][]string{"*": {"transfer(address,uint256)"}}},
+			tx:   []proto.Message{callToken(token, "transfer(address,uint256)", recipient, 1)},
+		},
+		{
+			name: "method allowed by selector",
+			cfg:  &Config{AllowedMethods: map[string][]string{token.String(): {"0x095ea7b3"}}},
+			tx:   []proto.Message{callToken(token, "approve(address,uint256)", recipient, 1)},
+		},
+		{
+			name: "method allowed on another contract",
+			cfg:  &Config{AllowedMethods: map[string][]string{stranger.String(): {"approve(address,uint256)"}}},
+			tx:   []proto.Message{callToken(token, "approve(address,uint256)", recipient, 1)},
+			rule: "method",
+		},
+		{
+			name: "method rejected",
+			cfg:  &Config{AllowedMethods: map[string][]string{"*": {"transfer(address,uint256)"}}},
+			tx:   []proto.Message{callToken(token, "approve(address,uint256)", recipient, 1)},
+			rule: "method",
+		},
+		{
+			name: "method rules ignore transfers",
+			cfg:  &Config{AllowedMethods: map[string][]string{"*": {"transfer(address,uint256)"}}},
+			tx:   []proto.Message{transferTRX(recipient, 1)},
+		},
+		{
+			name: "trx per transaction limit",
+			cfg:  &Config{TRX: &LimitConfig{PerTransaction: "10"}},
+			tx:   []proto.Message{transferTRX(recipient, 11)},
+			rule: "per transaction limit",
+		},
+		{
+			name: "trx limit ignores trc10",
+			cfg:  &Config{TRX: &LimitConfig{PerTransaction: "10"}},
+			tx:   []proto.Message{transferTRC10("1002000", recipient, 11)},
+		},
+		{
+			name: "trc10 per transaction limit",
+			cfg:  &Config{TRC10: map[string]*LimitConfig{"1002000": {PerTransaction: "10"}}},
+			tx:   []proto.Message{transferTRC10("1002000", recipient, 11)},
+			rule: "per transaction limit",
+		},
+		{
+			name: "trc10 limit of another token",
+			cfg:  &Config{TRC10: map[string]*LimitConfig{"1002001": {PerTransaction: "10"}}},
+			tx:   []proto.Message{transferTRC10("1002000", recipient, 11)},
+		},
+		{
+			name: "trc10 call token value limit",
+			cfg:  &Config{TRC10: map[string]*LimitConfig{"1002000": {PerTransaction: "10"}}},
+			tx: []proto.Message{&core.TriggerSmartContract{
+				OwnerAddress:    owner,
+				ContractAddress: token,
+				CallTokenValue:  11,
+				TokenId:         1002000,
+			}},
+			rule: "per transaction limit",
+		},
+		{
+			name: "trc20 per transaction limit",
+			cfg:  &Config{TRC20: map[string]*LimitConfig{token.String(): {PerTransaction: "10"}}},
+			tx:   []proto.Message{callToken(token, "transfer(address,uint256)", recipient, 11)},
+			rule: "per transaction limit",
+		},
+		{
+			name: "trc20 approve per transaction limit",
+			cfg:  &Config{TRC20: map[string]*LimitConfig{token.String(): {PerTransaction: "10"}}},
+			tx:   []proto.Message{callToken(token, "approve(address,uint256)", recipient, 11)},
+			rule: "per transaction limit",
+		},
+		{
+			name: "trc20 approve is not a daily spend",
+			cfg:  &Config{TRC20: map[string]*LimitConfig{token.String(): {Daily: "10"}}},
+			tx:   []proto.Message{callToken(token, "approve(address,uint256)", recipient, 11)},
+		},
+		{
+			name: "trc20 daily limit",
+			cfg:  &Config{TRC20: map[string]*LimitConfig{token.String(): {Daily: "10"}}},
+			tx:   []proto.Message{callToken(token, "transfer(address,uint256)", recipient, 11)},
+			rule: "daily limit",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newPolicy(t, test.cfg)
+
+			err := p.Check(newTx(t, test.tx...))
+			if test.rule == "" {
+				if err != nil {
+					t.Fatalf("Check() = %v", err)
+				}
+				return
+			}
+
+			var rejection *RejectionError
+			if !errors.As(err, &rejection) {
+				t.Fatalf("Check() = %v, want a %s rejection", err, test.rule)
+			}
+			if rejection.Rule != test.rule {
+				t.Errorf("Check() rejected by %s, want %s: %v", rejection.Rule, test.rule, err)
+			}
+		})
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	tests := []*Config{
+		{AllowedContractTypes: []string{"Transfer"}},
+		{DestinationAllowlist: []string{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6"}},
+		{TRX: &LimitConfig{Daily: "-1"}},
+		{TRX: &LimitConfig{PerTransaction: "1.5"}},
+		{TRC10: map[string]*LimitConfig{"TRX": {}}},
+		{TRC20: map[string]*LimitConfig{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6": {}}},
+		{AllowedMethods: map[string][]string{"*": {"0x095ea7"}}},
+	}
+
+	for i, cfg := range tests {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(tests[%d]) accepted an invalid config", i)
+		}
+	}
+}
+
+func TestDailyLimit(t *testing.T) {
+	p := newPolicy(t, &Config{
+		TRX:   &LimitConfig{Daily: "100"},
+		TRC10: map[string]*LimitConfig{"1002000": {Daily: "100"}},
+	})
+
+	steps := []struct {
+		tx     proto.Message
+		reject bool
+	}{
+		{transferTRX(recipient, 60), false},
+		{transferTRX(recipient, 50), true},
+		{transferTRX(recipient, 40), false},
+		{transferTRX(recipient, 1), true},
+		// the TRC10 amounts have their own total
+		{transferTRC10("1002000", recipient, 100), false},
+		{transferTRC10("1002000", recipient, 1), true},
+	}
+
+	for i, step := range steps {
+		err := p.Approve(newTx(t, step.tx))
+		if step.reject != (err != nil) {
+			t.Fatalf("step %d: Approve() = %v, want rejected %v", i, err, step.reject)
+		}
+	}
+
+	// the totals are reset the next UTC day
+	p.Now = func() time.Time { return time.Date(2022, 5, 2, 0, 0, 0, 0, time.UTC) }
+	if err := p.Approve(newTx(t, transferTRX(recipient, 100))); err != nil {
+		t.Fatalf("Approve() the next day = %v", err)
+	}
+}
+
+// failingSigner fails to sign after running sign
+type failingSigner struct {
+	sign func()
+}
+
+func (s *failingSigner) GetAddress() address.Address {
+	return owner
+}
+
+func (s *failingSigner) SignTx(tx *core.Transaction) (*core.Transaction, error) {
+	s.sign()
+	return nil, errors.New("signing failed")
+}
+
+func TestSignerRelease(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	p := newPolicy(t, &Config{TRX: &LimitConfig{Daily: "100"}})
+	p.Now = func() time.Time { return now }
+
+	inner := &failingSigner{sign: func() {}}
+	s := NewSigner(inner, p)
+
+	// a failed signature does not count against the limit
+	if _, err := s.SignTx(newTx(t, transferTRX(recipient, 60))); err == nil {
+		t.Fatal("SignTx() succeeded")
+	}
+	if err := p.Approve(newTx(t, transferTRX(recipient, 100))); err != nil {
+		t.Fatalf("Approve() after a failed signature = %v", err)
+	}
+
+	// the day rolls over while signing
+	now = time.Date(2022, 5, 1, 23, 59, 59, 0, time.UTC)
+	p = newPolicy(t, &Config{TRX: &LimitConfig{Daily: "100"}})
+	p.Now = func() time.Time { return now }
+	inner.sign = func() { now = now.Add(time.Second) }
+	s = NewSigner(inner, p)
+
+	if _, err := s.SignTx(newTx(t, transferTRX(recipient, 60))); err == nil {
+		t.Fatal("SignTx() succeeded")
+	}
+	if err := p.Approve(newTx(t, transferTRX(recipient, 100))); err != nil {
+		t.Fatalf("Approve() the next day = %v", err)
+	}
+
+	// releasing the previous day spend must not raise the limit of the new day
+	var rejection *RejectionError
+	if err := p.Approve(newTx(t, transferTRX(recipient, 1))); !errors.As(err, &rejection) || rejection.Rule != "daily limit" {
+		t.Fatalf("Approve() over the limit = %v, want a daily limit rejection", err)
+	}
+}
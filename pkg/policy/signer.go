@@ -0,0 +1,52 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/proto/core"
+)
+
+var _ keystore.ContextSigner = (*Signer)(nil)
+
+// Signer only signs transactions approved by its policy
+type Signer struct {
+	signer keystore.Signer
+	policy *Policy
+}
+
+// NewSigner wraps signer, usually a *keystore.Keystore, with policy
+func NewSigner(signer keystore.Signer, policy *Policy) *Signer {
+	return &Signer{
+		signer: signer,
+		policy: policy,
+	}
+}
+
+// GetAddress returns the address of the wrapped signer
+func (s *Signer) GetAddress() address.Address {
+	return s.signer.GetAddress()
+}
+
+// SignTx approves tx against the policy then signs it.
+// A rejected transaction returns a *RejectionError
+func (s *Signer) SignTx(tx *core.Transaction) (*core.Transaction, error) {
+	return s.SignTxWithContext(context.Background(), tx)
+}
+
+// SignTxWithContext is SignTx passing ctx on to the wrapped signer
+func (s *Signer) SignTxWithContext(ctx context.Context, tx *core.Transaction) (*core.Transaction, error) {
+	spends, err := s.policy.approve(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx, err := keystore.SignTxWithContext(ctx, s.signer, tx)
+	if err != nil {
+		s.policy.release(spends)
+		return nil, err
+	}
+
+	return signedTx, nil
+}
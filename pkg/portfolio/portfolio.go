@@ -0,0 +1,412 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/contract"
+	"github.com/craftto/go-tron/pkg/multicall"
+	"github.com/craftto/go-tron/pkg/token"
+	"github.com/craftto/go-tron/pkg/trc10"
+)
+
+// TRX is the token key of the TRX balances
+const TRX = "TRX"
+
+// DefaultConcurrency is the number of concurrent account queries
+const DefaultConcurrency = 8
+
+type Kind int
+
+const (
+	KindTRX Kind = iota
+	KindTRC10
+	KindTRC20
+)
+
+// Token describes a token of the report, keyed by "TRX", the TRC10 id or the TRC20 contract address
+type Token struct {
+	Key      string
+	Kind     Kind
+	Name     string
+	Symbol   string
+	Decimals int
+	// Err is the error of the metadata query, the balances of the token then hold it too
+	Err error
+}
+
+// Balance is the balance of an address for a token
+type Balance struct {
+	Amount token.Amount
+	Err    error
+}
+
+// Report is the balances of the addresses for each token.
+// TRC10 tokens are listed when at least one of the addresses holds them
+type Report struct {
+	Addresses []string
+	Tokens    map[string]*Token
+	// Balances maps the addresses to their balance of each token key
+	Balances map[string]map[string]Balance
+	// Totals maps the token keys to the sum of the balances queried successfully
+	Totals map[string]token.Amount
+	// FromBlock and ToBlock are the head blocks before and after the queries,
+	// the node does not allow to pin the queries to a block
+	FromBlock int64
+	ToBlock   int64
+}
+
+// Consistent reports whether all the balances were read at the same block
+func (r *Report) Consistent() bool {
+	return r.FromBlock == r.ToBlock
+}
+
+// TokenKeys returns the token keys sorted with TRX first, then the TRC10 and the TRC20 tokens
+func (r *Report) TokenKeys() []string {
+	keys := make([]string, 0, len(r.Tokens))
+	for key := range r.Tokens {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := r.Tokens[keys[i]], r.Tokens[keys[j]]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Key < b.Key
+	})
+
+	return keys
+}
+
+// Portfolio queries the balances of many addresses
+type Portfolio struct {
+	// Multicall aggregates the TRC20 queries, see multicall.New
+	Multicall *multicall.Multicall
+	// Concurrency is the number of concurrent account queries
+	Concurrency int
+	*client.GrpcClient
+}
+
+func New(mc *multicall.Multicall) *Portfolio {
+	return &Portfolio{
+		Multicall:   mc,
+		Concurrency: DefaultConcurrency,
+		GrpcClient:  mc.GrpcClient,
+	}
+}
+
+// Fetch returns the TRX, TRC10 and trc20Tokens balances of addresses.
+// Failed queries and invalid tokens are reported in the Balance and Token errors,
+// the returned error is for the failures of the whole report
+func (p *Portfolio) Fetch(addresses []string, trc20Tokens []string) (*Report, error) {
+	fromBlock, err := p.headBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Addresses: addresses,
+		Tokens:    map[string]*Token{TRX: {Key: TRX, Kind: KindTRX, Name: "Tronix", Symbol: TRX, Decimals: token.TRXDecimals}},
+		Balances:  make(map[string]map[string]Balance, len(addresses)),
+		Totals:    make(map[string]token.Amount),
+		FromBlock: fromBlock,
+	}
+	for _, addr := range addresses {
+		report.Balances[addr] = make(map[string]Balance)
+	}
+
+	trc10Balances := p.fetchAccounts(report)
+	p.fetchTRC10(report, trc10Balances)
+	if err := p.fetchTRC20(report, trc20Tokens); err != nil {
+		return nil, err
+	}
+
+	if report.ToBlock, err = p.headBlock(); err != nil {
+		return nil, err
+	}
+
+	for _, balances := range report.Balances {
+		for key, balance := range balances {
+			if balance.Err != nil {
+				continue
+			}
+			report.Totals[key] = report.Totals[key].Add(balance.Amount)
+		}
+	}
+
+	return report, nil
+}
+
+// fetchAccounts sets the TRX balances and returns the raw TRC10 balances of the addresses
+func (p *Portfolio) fetchAccounts(report *Report) []map[string]int64 {
+	trc10Balances := make([]map[string]int64, len(report.Addresses))
+	var mu sync.Mutex
+
+	p.forEach(len(report.Addresses), func(i int) {
+		addr := report.Addresses[i]
+
+		var balance Balance
+		acc, err := p.GetAccount(addr)
+		switch {
+		case errors.Is(err, client.ErrAccountNotFound):
+			balance.Amount = token.SUN(0)
+		case err != nil:
+			balance.Err = err
+		default:
+			balance.Amount = token.SUN(acc.GetBalance())
+			trc10Balances[i] = acc.GetAssetV2()
+		}
+
+		mu.Lock()
+		report.Balances[addr][TRX] = balance
+		mu.Unlock()
+	})
+
+	return trc10Balances
+}
+
+// fetchTRC10 sets the balances of the TRC10 tokens held by the addresses
+func (p *Portfolio) fetchTRC10(report *Report, trc10Balances []map[string]int64) {
+	ids := make([]string, 0)
+	for _, balances := range trc10Balances {
+		for id := range balances {
+			if _, ok := report.Tokens[id]; !ok {
+				report.Tokens[id] = &Token{Key: id, Kind: KindTRC10}
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	p.forEach(len(ids), func(i int) {
+		t := report.Tokens[ids[i]]
+
+		trc, err := trc10.NewTrc10(p.GrpcClient, t.Key)
+		if err != nil {
+			t.Err = err
+			return
+		}
+
+		t.Name = string(trc.Info.GetName())
+		t.Symbol = string(trc.Info.GetAbbr())
+		t.Decimals = int(trc.Info.GetPrecision())
+	})
+
+	for i, addr := range report.Addresses {
+		account := report.Balances[addr][TRX]
+		for _, id := range ids {
+			t := report.Tokens[id]
+			switch {
+			case account.Err != nil:
+				report.Balances[addr][id] = Balance{Err: account.Err}
+			case t.Err != nil:
+				report.Balances[addr][id] = Balance{Err: t.Err}
+			default:
+				report.Balances[addr][id] = Balance{Amount: token.NewAmount(big.NewInt(trc10Balances[i][id]), t.Decimals)}
+			}
+		}
+	}
+}
+
+// fetchTRC20 sets the balances of the tokens with one aggregated query for the metadata and one for the balances
+func (p *Portfolio) fetchTRC20(report *Report, tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	// the metadata calls of the token keys[i] start at calls[first[i]]
+	keys := make([]string, 0, len(tokens))
+	first := make([]int, 0, len(tokens))
+	calls := make([]multicall.Call, 0, len(tokens)*3)
+	for _, key := range tokens {
+		if _, ok := report.Tokens[key]; ok {
+			continue
+		}
+
+		t := &Token{Key: key, Kind: KindTRC20}
+		report.Tokens[key] = t
+		keys = append(keys, key)
+		first = append(first, len(calls))
+
+		if _, err := address.Parse(key); err != nil {
+			t.Err = fmt.Errorf("token %s: %v", key, err)
+			continue
+		}
+
+		for _, method := range []string{"name", "symbol", "decimals"} {
+			call, err := newCall(key, method)
+			if err != nil {
+				return err
+			}
+			calls = append(calls, call)
+		}
+	}
+	tokens = keys
+
+	results, err := p.Multicall.Aggregate(calls)
+	if err != nil {
+		return err
+	}
+
+	for i, key := range tokens {
+		t := report.Tokens[key]
+		if t.Err != nil {
+			continue
+		}
+		r := results[first[i]:]
+
+		name, err := decodeString("name", r[0])
+		var revert *abi.RevertError
+		switch {
+		case err == nil:
+			t.Name = name
+		case errors.As(err, &revert) || r[0].Success && len(r[0].ReturnData) == 0:
+			// the name is optional in the standard
+		default:
+			t.Err = fmt.Errorf("name(): %w", err)
+			continue
+		}
+
+		if t.Symbol, err = decodeString("symbol", r[1]); err != nil {
+			t.Err = fmt.Errorf("symbol(): %w", err)
+			continue
+		}
+
+		decimals, err := decodeResult[uint8]("decimals", r[2])
+		if err != nil {
+			t.Err = fmt.Errorf("decimals(): %w", err)
+			continue
+		}
+		t.Decimals = int(decimals)
+	}
+
+	// the balances of the failed tokens and of the invalid addresses are not queried
+	type balanceOf struct{ addr, key string }
+	queries := make([]balanceOf, 0, len(tokens)*len(report.Addresses))
+	calls = make([]multicall.Call, 0, len(tokens)*len(report.Addresses))
+	for _, key := range tokens {
+		t := report.Tokens[key]
+		for _, addr := range report.Addresses {
+			if t.Err != nil {
+				report.Balances[addr][key] = Balance{Err: t.Err}
+				continue
+			}
+
+			call, err := newCall(key, "balanceOf", addr)
+			if err != nil {
+				report.Balances[addr][key] = Balance{Err: err}
+				continue
+			}
+			calls = append(calls, call)
+			queries = append(queries, balanceOf{addr: addr, key: key})
+		}
+	}
+
+	results, err = p.Multicall.Aggregate(calls)
+	if err != nil {
+		return err
+	}
+
+	for i, q := range queries {
+		units, err := decodeResult[*big.Int]("balanceOf", results[i])
+		if err != nil {
+			report.Balances[q.addr][q.key] = Balance{Err: fmt.Errorf("balanceOf(%s): %w", q.addr, err)}
+			continue
+		}
+		report.Balances[q.addr][q.key] = Balance{Amount: token.NewAmount(units, report.Tokens[q.key].Decimals)}
+	}
+
+	return nil
+}
+
+// forEach runs f for 0 to n-1 with the concurrency of p
+func (p *Portfolio) forEach(n int, f func(i int)) {
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (p *Portfolio) headBlock() (int64, error) {
+	block, err := p.GetNowBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	return block.GetBlockHeader().GetRawData().GetNumber(), nil
+}
+
+// newCall returns the call of the TRC20 method on the tokenAddr contract
+func newCall(tokenAddr, method string, args ...interface{}) (multicall.Call, error) {
+	target, err := address.Parse(tokenAddr)
+	if err != nil {
+		return multicall.Call{}, fmt.Errorf("token %s: %v", tokenAddr, err)
+	}
+
+	m := abi.TRC20.Methods[method]
+	values, err := abi.ConvertArguments(m.Inputs, args)
+	if err != nil {
+		return multicall.Call{}, err
+	}
+
+	packed, err := m.Inputs.Pack(values...)
+	if err != nil {
+		return multicall.Call{}, err
+	}
+
+	data := make([]byte, 0, len(m.ID)+len(packed))
+	data = append(append(data, m.ID...), packed...)
+
+	return multicall.Call{Target: target, Data: data}, nil
+}
+
+// decodeString decodes the string output of the TRC20 method from r,
+// including the bytes32 strings of the early tokens
+func decodeString(method string, r multicall.Result) (string, error) {
+	s, err := decodeResult[string](method, r)
+	if err != nil && r.Success && len(r.ReturnData) == 32 {
+		return contract.ParseString(r.ReturnData)
+	}
+
+	return s, err
+}
+
+// decodeResult decodes the single output of the TRC20 method from r
+func decodeResult[T any](method string, r multicall.Result) (T, error) {
+	var v T
+	if !r.Success {
+		if r.Err == nil {
+			return v, errors.New("call failed")
+		}
+		return v, r.Err
+	}
+
+	out, err := contract.DecodeArguments(abi.TRC20.Methods[method].Outputs, r.ReturnData)
+	if err != nil {
+		return v, err
+	}
+
+	return contract.SingleOutput[T](out)
+}
@@ -0,0 +1,304 @@
+package portfolio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/multicall"
+	"github.com/craftto/go-tron/pkg/proto/api"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+)
+
+const (
+	alice   = "TKPmvcPZmPHHMYqWh6H1c458mBf2ozQnx9"
+	bob     = "TTh2wKxu8iUCsUsZD1mySpPocF329XBHWg"
+	invalid = "TInvalidAddress"
+
+	usdt        = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	weth        = "TKEerhofQTEicnAHg9HWdsMzm1mA6dBDwM"
+	noDecimals  = "TYjDQRHTjx6W9ogcAzcQ1qRtbSfJV7mwhh"
+	badContract = "not a contract"
+
+	knownTRC10   = "1002000"
+	unknownTRC10 = "1000001"
+)
+
+type stubToken struct {
+	name, symbol string
+	decimals     uint8
+	balances     map[string]*big.Int
+	// reverts are the methods which revert
+	reverts map[string]bool
+}
+
+// stubNode holds the accounts and the TRC10 and TRC20 tokens of the tests,
+// the head block advances by blockStep at each query
+type stubNode struct {
+	api.WalletClient
+
+	accounts  map[string]*core.Account
+	trc10     map[string]*core.AssetIssueContract
+	trc20     map[string]*stubToken
+	blockStep int64
+
+	mu    sync.Mutex
+	block int64
+}
+
+func mustParse(t *testing.T, addr string) address.Address {
+	t.Helper()
+
+	a, err := address.Parse(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func newStubNode(t *testing.T) *stubNode {
+	return &stubNode{
+		accounts: map[string]*core.Account{
+			alice: {
+				Address: mustParse(t, alice),
+				Balance: 1_500_000,
+				AssetV2: map[string]int64{knownTRC10: 250, unknownTRC10: 7},
+			},
+		},
+		trc10: map[string]*core.AssetIssueContract{
+			knownTRC10: {Id: knownTRC10, Name: []byte("BitTorrent"), Abbr: []byte("BTT"), Precision: 2},
+		},
+		trc20: map[string]*stubToken{
+			usdt: {
+				name: "Tether USD", symbol: "USDT", decimals: 6,
+				balances: map[string]*big.Int{alice: big.NewInt(12_500_000), bob: big.NewInt(1_250_000)},
+			},
+			weth: {
+				symbol: "WETH", decimals: 18,
+				balances: map[string]*big.Int{alice: big.NewInt(1), bob: new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)},
+				reverts:  map[string]bool{"name": true},
+			},
+			noDecimals: {
+				name: "Broken", symbol: "BRK",
+				reverts: map[string]bool{"decimals": true},
+			},
+		},
+	}
+}
+
+func (n *stubNode) GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	block := &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: 1000 + n.block}}}
+	n.block += n.blockStep
+	return block, nil
+}
+
+func (n *stubNode) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	if acc, ok := n.accounts[address.Address(in.Address).String()]; ok {
+		return acc, nil
+	}
+
+	// the nodes return an empty account for the unknown addresses
+	return &core.Account{}, nil
+}
+
+func (n *stubNode) GetAssetIssueById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.AssetIssueContract, error) {
+	if info, ok := n.trc10[string(in.Value)]; ok {
+		return info, nil
+	}
+
+	return &core.AssetIssueContract{}, nil
+}
+
+func (n *stubNode) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	tok, ok := n.trc20[address.Address(in.ContractAddress).String()]
+	if !ok {
+		// no contract, nothing is returned
+		return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{{}}}, nil
+	}
+
+	for name, m := range abi.TRC20.Methods {
+		if !bytes.Equal(in.Data[:4], m.ID) {
+			continue
+		}
+
+		if tok.reverts[name] {
+			return &api.TransactionExtention{
+				Result: &api.Return{Code: api.Return_CONTRACT_EXE_ERROR, Message: []byte("REVERT opcode executed")},
+				Transaction: &core.Transaction{
+					Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_REVERT}},
+				},
+			}, nil
+		}
+
+		var value interface{}
+		switch name {
+		case "name":
+			value = tok.name
+		case "symbol":
+			value = tok.symbol
+		case "decimals":
+			value = tok.decimals
+		case "balanceOf":
+			args, err := m.Inputs.Unpack(in.Data[4:])
+			if err != nil {
+				return nil, err
+			}
+			owner := address.EVMBytesToAddress(args[0].(ethcommon.Address).Bytes()).String()
+			balance := tok.balances[owner]
+			if balance == nil {
+				balance = new(big.Int)
+			}
+			value = balance
+		default:
+			return nil, errors.New("unexpected call of " + name)
+		}
+
+		packed, err := m.Outputs.Pack(value)
+		if err != nil {
+			return nil, err
+		}
+		return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{packed}}, nil
+	}
+
+	return nil, errors.New("unknown method")
+}
+
+func newPortfolio(t *testing.T, node *stubNode) *Portfolio {
+	t.Helper()
+
+	// without multicall contract, the calls are run individually
+	mc, err := multicall.New(&client.GrpcClient{Client: node}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return New(mc)
+}
+
+func TestFetch(t *testing.T) {
+	node := newStubNode(t)
+	addresses := []string{alice, bob, invalid}
+
+	report, err := newPortfolio(t, node).Fetch(addresses, []string{weth, usdt, noDecimals, badContract, usdt})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Consistent() {
+		t.Errorf("report from block %d to %d is not consistent", report.FromBlock, report.ToBlock)
+	}
+
+	// TRX, then the TRC10 and the TRC20 tokens sorted by key
+	wantKeys := []string{TRX, unknownTRC10, knownTRC10, weth, usdt, noDecimals, badContract}
+	if keys := report.TokenKeys(); !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("TokenKeys() = %v, want %v", keys, wantKeys)
+	}
+
+	tokens := []struct {
+		key          string
+		name, symbol string
+		decimals     int
+		err          bool
+	}{
+		{TRX, "Tronix", TRX, 6, false},
+		{knownTRC10, "BitTorrent", "BTT", 2, false},
+		{unknownTRC10, "", "", 0, true},
+		{usdt, "Tether USD", "USDT", 6, false},
+		// the name is optional
+		{weth, "", "WETH", 18, false},
+		{noDecimals, "Broken", "BRK", 0, true},
+		{badContract, "", "", 0, true},
+	}
+	for _, want := range tokens {
+		got := report.Tokens[want.key]
+		if got == nil {
+			t.Errorf("token %s is missing", want.key)
+			continue
+		}
+		if got.Name != want.name || got.Symbol != want.symbol || got.Decimals != want.decimals || (got.Err != nil) != want.err {
+			t.Errorf("token %s = %+v, want %+v", want.key, got, want)
+		}
+	}
+
+	balances := []struct {
+		addr, key string
+		want      string
+	}{
+		{alice, TRX, "1.5"},
+		{bob, TRX, "0"},
+		{alice, knownTRC10, "2.5"},
+		{bob, knownTRC10, "0"},
+		{alice, usdt, "12.5"},
+		{bob, usdt, "1.25"},
+		{alice, weth, "0.000000000000000001"},
+		{bob, weth, "1"},
+	}
+	for _, b := range balances {
+		got := report.Balances[b.addr][b.key]
+		if got.Err != nil || got.Amount.String() != b.want {
+			t.Errorf("balance of %s %s = %s, %v, want %s", b.addr, b.key, got.Amount, got.Err, b.want)
+		}
+	}
+
+	// the invalid address and the failed tokens report their error
+	for _, failed := range [][2]string{
+		{invalid, TRX},
+		{invalid, knownTRC10},
+		{invalid, usdt},
+		{alice, unknownTRC10},
+		{alice, noDecimals},
+		{bob, badContract},
+	} {
+		if got := report.Balances[failed[0]][failed[1]]; got.Err == nil {
+			t.Errorf("balance of %s %s = %s, want an error", failed[0], failed[1], got.Amount)
+		}
+	}
+
+	// the totals skip the errors and keep the token decimals
+	totals := []struct {
+		key      string
+		want     string
+		decimals int
+	}{
+		{TRX, "1.5", 6},
+		{knownTRC10, "2.5", 2},
+		{usdt, "13.75", 6},
+		{weth, "1.000000000000000001", 18},
+	}
+	for _, total := range totals {
+		got := report.Totals[total.key]
+		if got.String() != total.want || got.Decimals() != total.decimals {
+			t.Errorf("total of %s = %s with %d decimals, want %s with %d", total.key, got, got.Decimals(), total.want, total.decimals)
+		}
+	}
+	for _, key := range []string{unknownTRC10, noDecimals, badContract} {
+		if total, ok := report.Totals[key]; ok {
+			t.Errorf("total of the failed token %s = %s", key, total)
+		}
+	}
+}
+
+func TestFetchInconsistent(t *testing.T) {
+	node := newStubNode(t)
+	node.blockStep = 1
+
+	report, err := newPortfolio(t, node).Fetch([]string{alice}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Consistent() || report.FromBlock != 1000 || report.ToBlock != 1001 {
+		t.Errorf("report from block %d to %d", report.FromBlock, report.ToBlock)
+	}
+}
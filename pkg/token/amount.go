@@ -0,0 +1,146 @@
+package token
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TRXDecimals is the number of decimals of TRX, 1 TRX is 1_000_000 SUN
+const TRXDecimals = 6
+
+// Amount is an exact token amount held in the token base unit
+type Amount struct {
+	units    *big.Int
+	decimals int
+}
+
+// NewAmount returns the amount of units in the base unit of a token with decimals
+func NewAmount(units *big.Int, decimals int) Amount {
+	if units == nil {
+		units = new(big.Int)
+	}
+
+	return Amount{units: new(big.Int).Set(units), decimals: decimals}
+}
+
+// ParseAmount parses a decimal amount such as "12.345678" of a token with decimals,
+// amounts more precise than the token are rejected
+func ParseAmount(s string, decimals int) (Amount, error) {
+	units, err := parseUnits(s, decimals)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return Amount{units: units, decimals: decimals}, nil
+}
+
+// SUN returns the TRX amount of sun
+func SUN(sun int64) Amount {
+	return Amount{units: big.NewInt(sun), decimals: TRXDecimals}
+}
+
+// ParseTRX parses a decimal TRX amount such as "1.5"
+func ParseTRX(s string) (Amount, error) {
+	return ParseAmount(s, TRXDecimals)
+}
+
+// Units returns the amount in the token base unit
+func (a Amount) Units() *big.Int {
+	if a.units == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(a.units)
+}
+
+func (a Amount) Decimals() int {
+	return a.decimals
+}
+
+// Int64 returns the amount in the token base unit, such as the SUN of a TRX amount
+func (a Amount) Int64() (int64, error) {
+	units := a.Units()
+	if !units.IsInt64() {
+		return 0, fmt.Errorf("amount %s overflows int64", a)
+	}
+
+	return units.Int64(), nil
+}
+
+// String formats the amount exactly, "12.5" for 12_500_000 units of 6 decimals
+func (a Amount) String() string {
+	return formatUnits(a.units, a.decimals)
+}
+
+// Convert returns the amount with decimals, converting to less decimals
+// fails when the amount would be rounded
+func (a Amount) Convert(decimals int) (Amount, error) {
+	units := a.Units()
+	switch {
+	case decimals > a.decimals:
+		units.Mul(units, pow10(decimals-a.decimals))
+	case decimals < a.decimals:
+		var rem big.Int
+		units.QuoRem(units, pow10(a.decimals-decimals), &rem)
+		if rem.Sign() != 0 {
+			return Amount{}, fmt.Errorf("amount %s has more than %d decimals", a, decimals)
+		}
+	}
+
+	return Amount{units: units, decimals: decimals}, nil
+}
+
+// Add returns a+b, with the decimals of the most precise of both
+func (a Amount) Add(b Amount) Amount {
+	x, y := align(a, b)
+	x.units.Add(x.units, y.units)
+	return x
+}
+
+// Sub returns a-b, with the decimals of the most precise of both
+func (a Amount) Sub(b Amount) Amount {
+	x, y := align(a, b)
+	x.units.Sub(x.units, y.units)
+	return x
+}
+
+// Mul returns a*n
+func (a Amount) Mul(n int64) Amount {
+	units := a.Units()
+	units.Mul(units, big.NewInt(n))
+	return Amount{units: units, decimals: a.decimals}
+}
+
+// Cmp compares a and b and returns -1, 0 or +1
+func (a Amount) Cmp(b Amount) int {
+	x, y := align(a, b)
+	return x.units.Cmp(y.units)
+}
+
+func (a Amount) Sign() int {
+	if a.units == nil {
+		return 0
+	}
+
+	return a.units.Sign()
+}
+
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// align returns copies of a and b with the same decimals, which never rounds
+func align(a, b Amount) (Amount, Amount) {
+	decimals := a.decimals
+	if b.decimals > decimals {
+		decimals = b.decimals
+	}
+
+	x, _ := a.Convert(decimals)
+	y, _ := b.Convert(decimals)
+	return x, y
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,88 @@
+package token
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/transaction"
+)
+
+// Token is the common interface of the TRC10 and TRC20 tokens,
+// amounts are in the token base unit
+type Token interface {
+	GetName() (string, error)
+	GetSymbol() (string, error)
+	GetDecimals() (*big.Int, error)
+	GetTotalSupply() (*big.Int, error)
+	GetBalance(addr string) (*big.Int, error)
+	Transfer(ks keystore.Signer, to string, amount *big.Int) (*transaction.Transaction, error)
+}
+
+// parseUnits parses a decimal amount such as "12.345678" to the base unit of a
+// token with decimals, amounts more precise than the token are rejected
+func parseUnits(s string, decimals int) (*big.Int, error) {
+	if decimals < 0 {
+		return nil, fmt.Errorf("invalid decimals %d", decimals)
+	}
+
+	value := strings.TrimSpace(s)
+	negative := strings.HasPrefix(value, "-")
+	if negative || strings.HasPrefix(value, "+") {
+		value = value[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(value, ".")
+	if integer == "" && fraction == "" || !isDigits(integer) || !isDigits(fraction) {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", s, decimals)
+	}
+
+	units, ok := new(big.Int).SetString(integer+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	if negative {
+		units.Neg(units)
+	}
+
+	return units, nil
+}
+
+// formatUnits formats an amount in the base unit of a token with decimals,
+// trailing zeros of the fractional part are omitted
+func formatUnits(units *big.Int, decimals int) string {
+	if units == nil {
+		units = new(big.Int)
+	}
+
+	digits := new(big.Int).Abs(units).String()
+	if decimals > 0 {
+		if len(digits) <= decimals {
+			digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+		}
+		point := len(digits) - decimals
+		digits = strings.TrimSuffix(strings.TrimRight(digits[:point]+"."+digits[point:], "0"), ".")
+	}
+
+	if units.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,133 @@
+package token
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		s        string
+		decimals int
+		want     string
+	}{
+		{"12.345678", 6, "12345678"},
+		{"12.5", 6, "12500000"},
+		{"12", 6, "12000000"},
+		{"0", 6, "0"},
+		{"0.000001", 6, "1"},
+		{".5", 6, "500000"},
+		{"5.", 6, "5000000"},
+		{"-1.5", 6, "-1500000"},
+		{"+1.5", 6, "1500000"},
+		{"-0", 6, "0"},
+		{" 7 ", 6, "7000000"},
+		{"007.50", 2, "750"},
+		// trailing zeros are not extra precision
+		{"1.2300000", 2, "123"},
+		{"1.000", 0, "1"},
+		{"42", 0, "42"},
+		{"1", 18, "1000000000000000000"},
+		{"0.000000000000000001", 18, "1"},
+		{"123456789012345678901234567890.123456789012345678", 18, "123456789012345678901234567890123456789012345678"},
+	}
+
+	for _, test := range tests {
+		got, err := parseUnits(test.s, test.decimals)
+		if err != nil {
+			t.Errorf("parseUnits(%q, %d) = %v", test.s, test.decimals, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("parseUnits(%q, %d) = %s, want %s", test.s, test.decimals, got, test.want)
+		}
+	}
+}
+
+func TestParseUnitsInvalid(t *testing.T) {
+	tests := []struct {
+		s        string
+		decimals int
+	}{
+		// more precise than the token, never rounded
+		{"0.0000001", 6},
+		{"1.005", 2},
+		{"0.5", 0},
+		{"1.0000000000000000001", 18},
+		// malformed
+		{"", 6},
+		{".", 6},
+		{"-", 6},
+		{"-.", 6},
+		{"-+1", 6},
+		{"+-1", 6},
+		{"--1", 6},
+		{"1-", 6},
+		{"1.2.3", 6},
+		{"1,5", 6},
+		{"1e6", 6},
+		{"0x10", 6},
+		{"1 000", 6},
+		{"١", 6},
+		{"1", -1},
+	}
+
+	for _, test := range tests {
+		if got, err := parseUnits(test.s, test.decimals); err == nil {
+			t.Errorf("parseUnits(%q, %d) = %s, want an error", test.s, test.decimals, got)
+		}
+	}
+}
+
+func TestFormatUnits(t *testing.T) {
+	tests := []struct {
+		units    *big.Int
+		decimals int
+		want     string
+	}{
+		{big.NewInt(12_345_678), 6, "12.345678"},
+		{big.NewInt(12_500_000), 6, "12.5"},
+		{big.NewInt(12_000_000), 6, "12"},
+		{big.NewInt(1), 6, "0.000001"},
+		{big.NewInt(-1_500_000), 6, "-1.5"},
+		{big.NewInt(-1), 6, "-0.000001"},
+		{big.NewInt(0), 6, "0"},
+		{nil, 6, "0"},
+		{big.NewInt(42), 0, "42"},
+		{big.NewInt(-42), 0, "-42"},
+		{big.NewInt(1), 18, "0.000000000000000001"},
+		{new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil), 18, "1"},
+	}
+
+	for _, test := range tests {
+		if got := formatUnits(test.units, test.decimals); got != test.want {
+			t.Errorf("formatUnits(%s, %d) = %s, want %s", test.units, test.decimals, got, test.want)
+		}
+	}
+}
+
+func TestUnitsRoundTrip(t *testing.T) {
+	for _, decimals := range []int{0, 2, 6, 18} {
+		for _, s := range []string{
+			"0", "1", "-1", "10", "100.25", "-0.05", "99999999999999999999.99",
+			"0." + strings.Repeat("0", 17) + "1", "123456.123456789012345678",
+		} {
+			units, err := parseUnits(s, decimals)
+			if err != nil {
+				// more precise than decimals
+				continue
+			}
+
+			formatted := formatUnits(units, decimals)
+			again, err := parseUnits(formatted, decimals)
+			if err != nil {
+				t.Errorf("parseUnits(formatUnits(%q, %d)) = %v", s, decimals, err)
+				continue
+			}
+			if again.Cmp(units) != 0 {
+				t.Errorf("%q with %d decimals: %s formatted %s parsed %s", s, decimals, units, formatted, again)
+			}
+		}
+	}
+}
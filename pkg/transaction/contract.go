@@ -0,0 +1,151 @@
+package transaction
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	selectorTransfer     = []byte{0xa9, 0x05, 0x9c, 0xbb}
+	selectorTransferFrom = []byte{0x23, 0xb8, 0x72, 0xdd}
+	selectorApprove      = []byte{0x09, 0x5e, 0xa7, 0xb3}
+)
+
+// ContractSummary describes the contract carried by a transaction
+type ContractSummary struct {
+	Type  core.Transaction_Contract_ContractType `json:"type"`
+	Owner address.Address                        `json:"owner,omitempty"`
+	// To is the recipient of TRX and TRC10 transfers or the called contract
+	To address.Address `json:"to,omitempty"`
+	// Amount is the TRX amount in SUN, the call value or the TRC10 amount
+	Amount  int64  `json:"amount"`
+	AssetID string `json:"assetId,omitempty"`
+	// TokenValue is the TRC10 amount of AssetID sent with a smart contract call
+	TokenValue int64 `json:"tokenValue,omitempty"`
+	// Method is the 4 bytes selector of a smart contract call
+	Method []byte `json:"method,omitempty"`
+	// Token is set when the call data is a TRC20 transfer, transferFrom or approve
+	Token *TokenCall `json:"token,omitempty"`
+}
+
+// TokenCall is a decoded TRC20 transfer, transferFrom or approve call
+type TokenCall struct {
+	Method    string          `json:"method"`
+	From      address.Address `json:"from,omitempty"`
+	Recipient address.Address `json:"recipient"`
+	Amount    *big.Int        `json:"amount"`
+}
+
+// Recipient returns the address receiving value from the transaction:
+// the TRC20 recipient or spender when decoded, the destination otherwise
+func (s *ContractSummary) Recipient() address.Address {
+	if s.Token != nil {
+		return s.Token.Recipient
+	}
+
+	return s.To
+}
+
+// DecodeContract decodes the contract of tx, the transactions with more than
+// one contract are rejected as they are by the nodes
+func DecodeContract(tx *core.Transaction) (*ContractSummary, error) {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("transaction has no contract")
+	}
+	if len(contracts) > 1 {
+		return nil, fmt.Errorf("transaction has %d contracts", len(contracts))
+	}
+
+	c := contracts[0]
+	summary := &ContractSummary{
+		Type: c.GetType(),
+	}
+
+	value := c.GetParameter().GetValue()
+
+	switch c.GetType() {
+	case core.Transaction_Contract_TransferContract:
+		ct := new(core.TransferContract)
+		if err := proto.Unmarshal(value, ct); err != nil {
+			return nil, err
+		}
+		summary.Owner = ct.OwnerAddress
+		summary.To = ct.ToAddress
+		summary.Amount = ct.Amount
+	case core.Transaction_Contract_TransferAssetContract:
+		ct := new(core.TransferAssetContract)
+		if err := proto.Unmarshal(value, ct); err != nil {
+			return nil, err
+		}
+		summary.Owner = ct.OwnerAddress
+		summary.To = ct.ToAddress
+		summary.Amount = ct.Amount
+		summary.AssetID = string(ct.AssetName)
+	case core.Transaction_Contract_TriggerSmartContract:
+		ct := new(core.TriggerSmartContract)
+		if err := proto.Unmarshal(value, ct); err != nil {
+			return nil, err
+		}
+		summary.Owner = ct.OwnerAddress
+		summary.To = ct.ContractAddress
+		summary.Amount = ct.CallValue
+		if ct.TokenId > 0 {
+			summary.AssetID = fmt.Sprintf("%d", ct.TokenId)
+			summary.TokenValue = ct.CallTokenValue
+		}
+		if len(ct.Data) >= 4 {
+			summary.Method = ct.Data[:4]
+			summary.Token = decodeTokenCall(ct.Data)
+		}
+	case core.Transaction_Contract_CreateSmartContract:
+		ct := new(core.CreateSmartContract)
+		if err := proto.Unmarshal(value, ct); err != nil {
+			return nil, err
+		}
+		summary.Owner = ct.OwnerAddress
+		summary.Amount = ct.GetNewContract().GetCallValue()
+	}
+
+	return summary, nil
+}
+
+func decodeTokenCall(data []byte) *TokenCall {
+	selector, args := data[:4], data[4:]
+
+	word := func(i int) []byte {
+		return args[32*i : 32*(i+1)]
+	}
+	addr := func(i int) address.Address {
+		return append([]byte{address.TronBytePrefix}, word(i)[12:]...)
+	}
+
+	switch {
+	case bytes.Equal(selector, selectorTransfer) && len(args) >= 64:
+		return &TokenCall{
+			Method:    "transfer",
+			Recipient: addr(0),
+			Amount:    new(big.Int).SetBytes(word(1)),
+		}
+	case bytes.Equal(selector, selectorApprove) && len(args) >= 64:
+		return &TokenCall{
+			Method:    "approve",
+			Recipient: addr(0),
+			Amount:    new(big.Int).SetBytes(word(1)),
+		}
+	case bytes.Equal(selector, selectorTransferFrom) && len(args) >= 96:
+		return &TokenCall{
+			Method:    "transferFrom",
+			From:      addr(0),
+			Recipient: addr(1),
+			Amount:    new(big.Int).SetBytes(word(2)),
+		}
+	}
+
+	return nil
+}
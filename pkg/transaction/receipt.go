@@ -1,9 +1,13 @@
 package transaction
 
 import (
+	"errors"
+
+	"github.com/craftto/go-tron/pkg/abi"
 	"github.com/craftto/go-tron/pkg/address"
 	"github.com/craftto/go-tron/pkg/common"
 	"github.com/craftto/go-tron/pkg/proto/core"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
 )
 
 type TransactionReceipt struct {
@@ -15,6 +19,7 @@ type TransactionReceipt struct {
 	ComtractAddress               string
 	Receipt                       *core.ResourceReceipt
 	Logs                          []Log
+	ContractResult                []byte
 	ResMessage                    []byte
 	AssetIssueID                  string
 	WithdrawAmount                int64
@@ -57,11 +62,15 @@ func GetTransactionReceipt(tx *core.TransactionInfo) (*TransactionReceipt, error
 		receipt.ComtractAddress = address.Address(tx.GetContractAddress()).Base58()
 	}
 
+	if contractResult := tx.GetContractResult(); len(contractResult) > 0 {
+		receipt.ContractResult = contractResult[0]
+	}
+
 	if tx.GetLog() != nil {
 		logs := make([]Log, 0, len(tx.Log))
 		for _, log := range tx.Log {
 			l := Log{
-				Address: address.Address(log.Address),
+				Address: address.EVMBytesToAddress(log.Address),
 				Topics:  make([]string, 0, len(log.Topics)),
 				Data:    common.Bytes2Hex(log.Data),
 			}
@@ -78,3 +87,62 @@ func GetTransactionReceipt(tx *core.TransactionInfo) (*TransactionReceipt, error
 
 	return receipt, nil
 }
+
+// RevertError returns the decoded revert of a failed contract execution,
+// with the custom errors of a when not nil. It returns nil otherwise
+func (r *TransactionReceipt) RevertError(a *ethabi.ABI) error {
+	if r.Receipt.GetResult() != core.Transaction_Result_REVERT {
+		return nil
+	}
+
+	// the revert data is returned in the contract result, ResMessage holds the node message
+	if abi.IsRevertData(a, r.ContractResult) || len(r.ResMessage) == 0 {
+		return abi.DecodeRevert(a, r.ContractResult)
+	}
+
+	return abi.DecodeRevert(a, r.ResMessage)
+}
+
+// Decode decodes the log with the matching event of a, or with the
+// token standard events of abi.StandardABIs when a is nil
+func (l Log) Decode(a *ethabi.ABI) (*abi.Event, error) {
+	topics := make([][]byte, 0, len(l.Topics))
+	for _, topic := range l.Topics {
+		t, err := common.Hex2Bytes(topic)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, t)
+	}
+
+	data, err := common.Hex2Bytes(l.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return abi.DecodeLog(a, l.Address, topics, data)
+}
+
+// DecodeLogs decodes the logs matching an event of a, or the token standard
+// events when a is nil. Logs without a matching event are skipped
+func (r *TransactionReceipt) DecodeLogs(a *ethabi.ABI) ([]*abi.Event, error) {
+	events := make([]*abi.Event, 0, len(r.Logs))
+	for _, l := range r.Logs {
+		event, err := l.Decode(a)
+		if errors.Is(err, abi.ErrEventNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// DecodeTransactionInfoLog decodes a raw log with the matching event of a,
+// or with the token standard events when a is nil
+func DecodeTransactionInfoLog(log *core.TransactionInfo_Log, a *ethabi.ABI) (*abi.Event, error) {
+	return abi.DecodeLog(a, log.GetAddress(), log.GetTopics(), log.GetData())
+}
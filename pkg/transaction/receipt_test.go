@@ -0,0 +1,103 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/proto/core"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+// transactionInfo returns the info of a USDT transfer of 12.5 USDT, followed by
+// a log of an unknown event
+func transactionInfo(t *testing.T) *core.TransactionInfo {
+	return &core.TransactionInfo{
+		Id:     mustHex(t, "1b5b9ccb3e8d006a5230de9bda23ff91edc794d4f56410560830b418528e446c"),
+		Result: core.TransactionInfo_SUCESS,
+		Log: []*core.TransactionInfo_Log{
+			{
+				Address: mustHex(t, "a614f803b6fd780986a42c78ec9c7f77e6ded13c"),
+				Topics: [][]byte{
+					mustHex(t, "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+					mustHex(t, "0000000000000000000000003b32a0ae2f5d4d9f2f8c7bc3a0d75b1e2a8dc0a4"),
+					mustHex(t, "000000000000000000000000e5e4a7ac8b6c1d53f2f2a9e0c1b7a92d5b1a0f11"),
+				},
+				Data: mustHex(t, "0000000000000000000000000000000000000000000000000000000000bebc20"),
+			},
+			{
+				Address: mustHex(t, "a614f803b6fd780986a42c78ec9c7f77e6ded13c"),
+				Topics: [][]byte{
+					mustHex(t, "0000000000000000000000000000000000000000000000000000000000000001"),
+				},
+			},
+		},
+	}
+}
+
+func TestGetTransactionReceiptLogs(t *testing.T) {
+	receipt, err := GetTransactionReceipt(transactionInfo(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(receipt.Logs) != 2 {
+		t.Fatalf("got %d logs, want 2", len(receipt.Logs))
+	}
+
+	// the nodes return the 20 bytes EVM address of the contract
+	l := receipt.Logs[0]
+	if l.Address.String() != "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t" || !l.Address.IsValid() {
+		t.Errorf("Log.Address = %x, want the 0x41 prefixed address", []byte(l.Address))
+	}
+	if len(l.Topics) != 3 || l.Topics[0] != "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
+		t.Errorf("Log.Topics = %v", l.Topics)
+	}
+}
+
+func TestDecodeLogs(t *testing.T) {
+	receipt, err := GetTransactionReceipt(transactionInfo(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the unknown event is skipped
+	events, err := receipt.DecodeLogs(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	event := events[0]
+	if event.Signature != "Transfer(address,address,uint256)" || event.Address.String() != "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t" {
+		t.Errorf("event = %s from %s", event.Signature, event.Address)
+	}
+	if value := event.Args["value"].(*big.Int); value.Int64() != 12_500_000 {
+		t.Errorf("value = %s", value)
+	}
+	if to := event.Args["to"].(address.Address); to.Hex() != "0x41e5e4a7ac8b6c1d53f2f2a9e0c1b7a92d5b1a0f11" {
+		t.Errorf("to = %s", to.Hex())
+	}
+
+	// the raw log of the node decodes the same
+	raw, err := DecodeTransactionInfoLog(transactionInfo(t).Log[0], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.Address.Hex() != event.Address.Hex() || raw.Args["value"].(*big.Int).Cmp(event.Args["value"].(*big.Int)) != 0 {
+		t.Errorf("DecodeTransactionInfoLog() = %+v, want %+v", raw, event)
+	}
+}
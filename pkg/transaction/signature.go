@@ -0,0 +1,185 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Signer is an account recovered from one of the transaction signatures
+type Signer struct {
+	Address   address.Address
+	PublicKey *ecdsa.PublicKey
+	Signature []byte
+}
+
+// PermissionCheck is the result of matching the signers of a transaction
+// against an account permission
+type PermissionCheck struct {
+	Signers   []Signer
+	Weight    int64
+	Threshold int64
+	// Unknown lists the signers that are not keys of the permission
+	Unknown []address.Address
+	// Approved reports whether the signed weight reaches the threshold
+	Approved bool
+}
+
+// RawDataHash returns the sha256 hash of the transaction raw data,
+// which is both the txid and the digest signed by Keystore.SignTx
+func RawDataHash(tx *core.Transaction) ([]byte, error) {
+	if tx.GetRawData() == nil {
+		return nil, fmt.Errorf("transaction raw data is empty")
+	}
+
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+
+	h256h := sha256.New()
+	h256h.Write(rawData)
+
+	return h256h.Sum(nil), nil
+}
+
+// RecoverSigners returns the signers of every entry in tx.Signature
+func RecoverSigners(tx *core.Transaction) ([]Signer, error) {
+	hash, err := RawDataHash(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make([]Signer, 0, len(tx.GetSignature()))
+	for i, sig := range tx.GetSignature() {
+		if len(sig) != crypto.SignatureLength {
+			return nil, fmt.Errorf("invalid signature %d: length %d", i, len(sig))
+		}
+
+		// signatures produced by other tools may use the 27/28 recovery id
+		normalized := make([]byte, len(sig))
+		copy(normalized, sig)
+		if normalized[64] >= 27 {
+			normalized[64] -= 27
+		}
+
+		pub, err := crypto.SigToPub(hash, normalized)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %d: %v", i, err)
+		}
+
+		signers = append(signers, Signer{
+			Address:   address.PubkeyToAddress(*pub),
+			PublicKey: pub,
+			Signature: sig,
+		})
+	}
+
+	return signers, nil
+}
+
+// VerifyPermission recovers the signers of tx and checks them against the
+// keys, weights and threshold of perm
+func VerifyPermission(tx *core.Transaction, perm *core.Permission) (*PermissionCheck, error) {
+	if perm == nil {
+		return nil, fmt.Errorf("permission is empty")
+	}
+
+	signers, err := RecoverSigners(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &PermissionCheck{
+		Signers:   signers,
+		Threshold: perm.GetThreshold(),
+		Unknown:   make([]address.Address, 0),
+	}
+
+	counted := make([]address.Address, 0, len(signers))
+	for _, signer := range signers {
+		duplicated := false
+		for _, addr := range counted {
+			if bytes.Equal(addr, signer.Address) {
+				duplicated = true
+				break
+			}
+		}
+		if duplicated {
+			continue
+		}
+		counted = append(counted, signer.Address)
+
+		found := false
+		for _, key := range perm.GetKeys() {
+			if bytes.Equal(key.GetAddress(), signer.Address) {
+				check.Weight += key.GetWeight()
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			check.Unknown = append(check.Unknown, signer.Address)
+		}
+	}
+
+	check.Approved = check.Threshold > 0 && check.Weight >= check.Threshold
+
+	return check, nil
+}
+
+// VerifyAccountPermission checks the signers of tx against the permission
+// of acc referenced by the transaction contract Permission_id
+func VerifyAccountPermission(tx *core.Transaction, acc *core.Account) (*PermissionCheck, error) {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("transaction has no contract")
+	}
+
+	perm, err := GetPermission(acc, contracts[0].GetPermissionId())
+	if err != nil {
+		return nil, err
+	}
+
+	return VerifyPermission(tx, perm)
+}
+
+// GetPermission returns the account permission with the given id.
+// Owner is id 0, witness id 1 and actives start from id 2
+func GetPermission(acc *core.Account, id int32) (*core.Permission, error) {
+	switch id {
+	case 0:
+		if acc.GetOwnerPermission() != nil {
+			return acc.GetOwnerPermission(), nil
+		}
+
+		// accounts without updated permissions are owned by their own key
+		return &core.Permission{
+			Type:           core.Permission_Owner,
+			PermissionName: "owner",
+			Threshold:      1,
+			Keys: []*core.Key{
+				{Address: acc.GetAddress(), Weight: 1},
+			},
+		}, nil
+	case 1:
+		if acc.GetWitnessPermission() != nil {
+			return acc.GetWitnessPermission(), nil
+		}
+	default:
+		for _, perm := range acc.GetActivePermission() {
+			if perm.GetId() == id {
+				return perm, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("permission %d not found", id)
+}
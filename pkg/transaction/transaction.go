@@ -1,10 +1,9 @@
 package transaction
 
 import (
-	"crypto/sha256"
+	"fmt"
 
 	"github.com/craftto/go-tron/pkg/proto/api"
-	"google.golang.org/protobuf/proto"
 )
 
 type Transaction struct {
@@ -14,15 +13,22 @@ type Transaction struct {
 }
 
 func UpdateTxHash(tx *api.TransactionExtention) error {
-	rawData, err := proto.Marshal(tx.Transaction.GetRawData())
+	hash, err := RawDataHash(tx.Transaction)
 	if err != nil {
 		return err
 	}
-
-	h256h := sha256.New()
-	h256h.Write(rawData)
-	hash := h256h.Sum(nil)
 	tx.Txid = hash
 
 	return nil
 }
+
+// SetMemo sets the memo of the unsigned tx and updates its txid
+func SetMemo(tx *api.TransactionExtention, memo string) error {
+	if tx.GetTransaction().GetRawData() == nil {
+		return fmt.Errorf("transaction raw data is empty")
+	}
+
+	tx.Transaction.RawData.Data = []byte(memo)
+
+	return UpdateTxHash(tx)
+}
@@ -0,0 +1,145 @@
+package trc10
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/craftto/go-tron/pkg/token"
+	"github.com/craftto/go-tron/pkg/transaction"
+)
+
+var _ token.Token = (*TRC10)(nil)
+
+// TRC10 is a TRC10 token, its issue metadata is loaded once on creation
+type TRC10 struct {
+	TokenID string
+	Info    *core.AssetIssueContract
+	*client.GrpcClient
+}
+
+func NewTrc10(g *client.GrpcClient, tokenID string) (*TRC10, error) {
+	if _, err := strconv.ParseInt(tokenID, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid token id %q", tokenID)
+	}
+
+	info, err := g.GetAssetIssueByID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if info.GetId() != tokenID {
+		return nil, fmt.Errorf("token %s not found", tokenID)
+	}
+
+	return &TRC10{
+		TokenID:    tokenID,
+		Info:       info,
+		GrpcClient: g,
+	}, nil
+}
+
+func (t *TRC10) GetName() (string, error) {
+	return string(t.Info.GetName()), nil
+}
+
+// GetSymbol returns the token abbreviation
+func (t *TRC10) GetSymbol() (string, error) {
+	return string(t.Info.GetAbbr()), nil
+}
+
+// GetDecimals returns the token precision
+func (t *TRC10) GetDecimals() (*big.Int, error) {
+	return big.NewInt(int64(t.Info.GetPrecision())), nil
+}
+
+func (t *TRC10) GetTotalSupply() (*big.Int, error) {
+	return big.NewInt(t.Info.GetTotalSupply()), nil
+}
+
+// GetOwner returns the issuer of the token
+func (t *TRC10) GetOwner() address.Address {
+	return address.Address(t.Info.GetOwnerAddress())
+}
+
+// GetBalance returns the balance of addr, zero for the accounts not activated
+func (t *TRC10) GetBalance(addr string) (*big.Int, error) {
+	acc, err := t.GetAccount(addr)
+	if errors.Is(err, client.ErrAccountNotFound) {
+		return new(big.Int), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(acc.GetAssetV2()[t.TokenID]), nil
+}
+
+// Transfer sends amount of the token in its base unit
+func (t *TRC10) Transfer(ks keystore.Signer, to string, amount *big.Int) (*transaction.Transaction, error) {
+	if !amount.IsInt64() || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid amount %s", amount)
+	}
+
+	tx, err := t.TransferAsset(ks.GetAddress().String(), to, t.TokenID, amount.Int64())
+	if err != nil {
+		return nil, err
+	}
+
+	return t.SignAndBroadcast(ks, tx)
+}
+
+// TransferDecimal sends a decimal amount of the token such as "12.5",
+// amounts more precise than the token are rejected
+func (t *TRC10) TransferDecimal(ks keystore.Signer, to, amount string) (*transaction.Transaction, error) {
+	parsed, err := t.ParseAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.TransferAmount(ks, to, parsed)
+}
+
+// Participate buys the token from the issuer during its issuing period,
+// amount is the TRX paid in SUN
+func (t *TRC10) Participate(ks keystore.Signer, amount int64) (*transaction.Transaction, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("invalid amount %d", amount)
+	}
+
+	tx, err := t.ParticipateAssetIssue(ks.GetAddress().String(), t.GetOwner().String(), t.TokenID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.SignAndBroadcast(ks, tx)
+}
+
+// ParseAmount parses a decimal amount such as "12.5" with the token precision
+func (t *TRC10) ParseAmount(s string) (token.Amount, error) {
+	return token.ParseAmount(s, int(t.Info.GetPrecision()))
+}
+
+// GetBalanceAmount returns the balance of addr with the token precision
+func (t *TRC10) GetBalanceAmount(addr string) (token.Amount, error) {
+	balance, err := t.GetBalance(addr)
+	if err != nil {
+		return token.Amount{}, err
+	}
+
+	return token.NewAmount(balance, int(t.Info.GetPrecision())), nil
+}
+
+// TransferAmount sends amount, which must not be more precise than the token
+func (t *TRC10) TransferAmount(ks keystore.Signer, to string, amount token.Amount) (*transaction.Transaction, error) {
+	converted, err := amount.Convert(int(t.Info.GetPrecision()))
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Transfer(ks, to, converted.Units())
+}
@@ -0,0 +1,117 @@
+package trc1155
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/transaction"
+)
+
+// TransferSingleEvent is the TransferSingle(address,address,address,uint256,uint256) event
+type TransferSingleEvent struct {
+	Operator address.Address
+	From     address.Address
+	To       address.Address
+	ID       *big.Int
+	Value    *big.Int
+	Raw      *abi.Event
+}
+
+// TransferBatchEvent is the TransferBatch(address,address,address,uint256[],uint256[]) event
+type TransferBatchEvent struct {
+	Operator address.Address
+	From     address.Address
+	To       address.Address
+	IDs      []*big.Int
+	Values   []*big.Int
+	Raw      *abi.Event
+}
+
+// ParseTransferSingle decodes a TransferSingle log, abi.ErrEventNotFound is returned for other logs
+func ParseTransferSingle(log transaction.Log) (*TransferSingleEvent, error) {
+	event, err := decode(log, "TransferSingle")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TransferSingleEvent{Raw: event}
+	if err := event.Scan(&result.Operator, &result.From, &result.To, &result.ID, &result.Value); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ParseTransferBatch decodes a TransferBatch log, abi.ErrEventNotFound is returned for other logs
+func ParseTransferBatch(log transaction.Log) (*TransferBatchEvent, error) {
+	event, err := decode(log, "TransferBatch")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TransferBatchEvent{Raw: event}
+	if err := event.Scan(&result.Operator, &result.From, &result.To, &result.IDs, &result.Values); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FilterTransfers returns the transfers of the receipt emitted by the contract,
+// with the batches flattened to single transfers
+func (t *TRC1155) FilterTransfers(receipt *transaction.TransactionReceipt) ([]*TransferSingleEvent, error) {
+	events := make([]*TransferSingleEvent, 0)
+	for _, log := range receipt.Logs {
+		if !bytes.Equal(log.Address, t.Address) {
+			continue
+		}
+
+		single, err := ParseTransferSingle(log)
+		if err == nil {
+			events = append(events, single)
+			continue
+		}
+		if !errors.Is(err, abi.ErrEventNotFound) {
+			return nil, err
+		}
+
+		batch, err := ParseTransferBatch(log)
+		if errors.Is(err, abi.ErrEventNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range batch.IDs {
+			if i >= len(batch.Values) {
+				break
+			}
+			events = append(events, &TransferSingleEvent{
+				Operator: batch.Operator,
+				From:     batch.From,
+				To:       batch.To,
+				ID:       batch.IDs[i],
+				Value:    batch.Values[i],
+				Raw:      batch.Raw,
+			})
+		}
+	}
+
+	return events, nil
+}
+
+func decode(log transaction.Log, name string) (*abi.Event, error) {
+	event, err := log.Decode(abi.TRC1155)
+	if err != nil {
+		return nil, err
+	}
+	if event.Name != name {
+		return nil, abi.ErrEventNotFound
+	}
+
+	return event, nil
+}
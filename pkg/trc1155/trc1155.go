@@ -0,0 +1,116 @@
+package trc1155
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/contract"
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/transaction"
+)
+
+// ERC165 interface ids, see contract.InterfaceIDERC165
+var (
+	InterfaceIDTRC1155     = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	InterfaceIDMetadataURI = [4]byte{0x0e, 0x89, 0x34, 0x1c}
+)
+
+type TRC1155 struct {
+	*contract.Contract
+}
+
+func NewTrc1155(g *client.GrpcClient, contractAddr string) (*TRC1155, error) {
+	c, err := contract.NewWithABI(g, contractAddr, abi.TRC1155)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TRC1155{Contract: c}, nil
+}
+
+// IsTRC1155 reports whether the contract declares the TRC1155 interface,
+// see contract.Contract.SupportsInterface
+func (t *TRC1155) IsTRC1155() (bool, error) {
+	return t.SupportsInterface(InterfaceIDTRC1155)
+}
+
+// GetBalance returns the balance of the token id owned by account
+func (t *TRC1155) GetBalance(account string, id *big.Int) (*big.Int, error) {
+	out, err := t.Contract.Call("balanceOf", account, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.SingleOutput[*big.Int](out)
+}
+
+// GetBalanceBatch returns the balances of the token ids[i] owned by accounts[i]
+func (t *TRC1155) GetBalanceBatch(accounts []string, ids []*big.Int) ([]*big.Int, error) {
+	if len(accounts) != len(ids) {
+		return nil, fmt.Errorf("expected as many accounts as ids, got %d and %d", len(accounts), len(ids))
+	}
+
+	out, err := t.Contract.Call("balanceOfBatch", accounts, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := contract.SingleOutput[[]*big.Int](out)
+	if err != nil {
+		return nil, err
+	}
+	if len(balances) != len(ids) {
+		return nil, fmt.Errorf("expected %d balances, got %d", len(ids), len(balances))
+	}
+
+	return balances, nil
+}
+
+// GetURI returns the metadata URI of the token id, with the {id} placeholder
+// to be replaced by the hex id as specified by the standard
+func (t *TRC1155) GetURI(id *big.Int) (string, error) {
+	out, err := t.Contract.Call("uri", id)
+	if err != nil {
+		return "", err
+	}
+
+	return contract.SingleOutput[string](out)
+}
+
+func (t *TRC1155) IsApprovedForAll(account, operator string) (bool, error) {
+	out, err := t.Contract.Call("isApprovedForAll", account, operator)
+	if err != nil {
+		return false, err
+	}
+
+	return contract.SingleOutput[bool](out)
+}
+
+func (t *TRC1155) SetApprovalForAll(ks keystore.Signer, operator string, approved bool) (*transaction.Transaction, error) {
+	return t.Contract.Transact(ks, "setApprovalForAll", operator, approved)
+}
+
+// SafeTransferFrom transfers amount of the token id, data is passed to the
+// onTRC1155Received hook of a receiving contract
+func (t *TRC1155) SafeTransferFrom(ks keystore.Signer, from, to string, id, amount *big.Int, data []byte) (*transaction.Transaction, error) {
+	if data == nil {
+		data = []byte{}
+	}
+
+	return t.Contract.Transact(ks, "safeTransferFrom", from, to, id, amount, data)
+}
+
+// SafeBatchTransferFrom transfers amounts[i] of the token ids[i], data is passed
+// to the onTRC1155BatchReceived hook of a receiving contract
+func (t *TRC1155) SafeBatchTransferFrom(ks keystore.Signer, from, to string, ids, amounts []*big.Int, data []byte) (*transaction.Transaction, error) {
+	if len(ids) != len(amounts) {
+		return nil, fmt.Errorf("expected as many ids as amounts, got %d and %d", len(ids), len(amounts))
+	}
+	if data == nil {
+		data = []byte{}
+	}
+
+	return t.Contract.Transact(ks, "safeBatchTransferFrom", from, to, ids, amounts, data)
+}
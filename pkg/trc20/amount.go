@@ -0,0 +1,81 @@
+package trc20
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/token"
+	"github.com/craftto/go-tron/pkg/transaction"
+)
+
+// ParseAmount parses a decimal amount such as "12.5" with the token decimals
+func (t *TRC20) ParseAmount(s string) (token.Amount, error) {
+	decimals, err := t.tokenDecimals()
+	if err != nil {
+		return token.Amount{}, err
+	}
+
+	return token.ParseAmount(s, decimals)
+}
+
+// GetBalanceAmount returns the balance of addr with the token decimals
+func (t *TRC20) GetBalanceAmount(addr string) (token.Amount, error) {
+	decimals, err := t.tokenDecimals()
+	if err != nil {
+		return token.Amount{}, err
+	}
+
+	balance, err := t.GetBalance(addr)
+	if err != nil {
+		return token.Amount{}, err
+	}
+
+	return token.NewAmount(balance, decimals), nil
+}
+
+// TransferAmount transfers amount, which must not be more precise than the token
+func (t *TRC20) TransferAmount(ks keystore.Signer, to string, amount token.Amount) (*transaction.Transaction, error) {
+	units, err := t.toUnits(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Transfer(ks, to, units)
+}
+
+// ApproveAmount approves amount, which must not be more precise than the token
+func (t *TRC20) ApproveAmount(ks keystore.Signer, spender string, amount token.Amount) (*transaction.Transaction, error) {
+	units, err := t.toUnits(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Approve(ks, spender, units)
+}
+
+func (t *TRC20) toUnits(amount token.Amount) (*big.Int, error) {
+	decimals, err := t.tokenDecimals()
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := amount.Convert(decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return converted.Units(), nil
+}
+
+func (t *TRC20) tokenDecimals() (int, error) {
+	decimals, err := t.GetDecimals()
+	if err != nil {
+		return 0, err
+	}
+	if !decimals.IsInt64() || decimals.Int64() > 77 {
+		return 0, fmt.Errorf("invalid token decimals %s", decimals)
+	}
+
+	return int(decimals.Int64()), nil
+}
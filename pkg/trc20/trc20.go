@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/craftto/go-tron/pkg/abi"
 	"github.com/craftto/go-tron/pkg/address"
@@ -11,8 +12,10 @@ import (
 	"github.com/craftto/go-tron/pkg/common"
 	"github.com/craftto/go-tron/pkg/contract"
 	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/multicall"
 	"github.com/craftto/go-tron/pkg/proto/api"
 	"github.com/craftto/go-tron/pkg/proto/core"
+	"github.com/craftto/go-tron/pkg/token"
 	"github.com/craftto/go-tron/pkg/transaction"
 )
 
@@ -32,9 +35,16 @@ var (
 	feeLimit int64 = 30_000_000
 )
 
+var _ token.Token = (*TRC20)(nil)
+
 type TRC20 struct {
 	ContractAddress address.Address
+	// Simulate runs Transfer and TransferFrom as a dry run before broadcasting them
+	Simulate bool
 	*client.GrpcClient
+
+	mu       sync.Mutex
+	decimals *big.Int
 }
 
 func NewTrc20(g *client.GrpcClient, contractAddr string) (*TRC20, error) {
@@ -77,18 +87,26 @@ func (t *TRC20) GetSymbol() (string, error) {
 	return contract.ParseString(result.GetConstantResult()[0])
 }
 
+// GetDecimals returns the token decimals, they are queried once and cached
 func (t *TRC20) GetDecimals() (*big.Int, error) {
-	data, err := common.Hex2Bytes(methodDecimals)
-	if err != nil {
-		return nil, err
-	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-	result, err := t.callConstant(data)
-	if err != nil {
-		return nil, err
+	if t.decimals == nil {
+		data, err := common.Hex2Bytes(methodDecimals)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := t.callConstant(data)
+		if err != nil {
+			return nil, err
+		}
+
+		t.decimals = contract.ParseInt(result.GetConstantResult()[0])
 	}
 
-	return contract.ParseInt(result.GetConstantResult()[0]), nil
+	return new(big.Int).Set(t.decimals), nil
 }
 
 func (t *TRC20) GetTotalSupply() (*big.Int, error) {
@@ -150,57 +168,166 @@ func (t *TRC20) GetAllowance(owner, spender string) (*big.Int, error) {
 	return contract.ParseInt(result.GetConstantResult()[0]), nil
 }
 
-func (t *TRC20) Approve(ks *keystore.Keystore, spender string, amount *big.Int) (*transaction.Transaction, error) {
-	param, err := abi.GetParams([]abi.Param{
-		{"address": spender},
-		{"uint256": amount},
-	})
+// GetBalances returns the balances of owners with one aggregated call per batch of mc
+func (t *TRC20) GetBalances(mc *multicall.Multicall, owners []string) ([]*big.Int, error) {
+	calls := make([]multicall.Call, 0, len(owners))
+	for _, owner := range owners {
+		addr, err := address.Base58ToAddress(owner)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := common.Hex2Bytes(methodBalanceOf + abi.PaddedParam(addr.Hex()[2:]))
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls, multicall.Call{Target: t.ContractAddress, Data: data})
+	}
+
+	results, err := mc.Aggregate(calls)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := common.Hex2Bytes(methodApprove)
+	balances := make([]*big.Int, 0, len(results))
+	for i, r := range results {
+		if !r.Success {
+			return nil, fmt.Errorf("balanceOf(%s): %w", owners[i], r.Err)
+		}
+		balances = append(balances, contract.ParseInt(r.ReturnData))
+	}
+
+	return balances, nil
+}
+
+// GetDecimalsBatch returns the decimals of tokens with one aggregated call per batch of mc
+func GetDecimalsBatch(mc *multicall.Multicall, tokens []string) ([]*big.Int, error) {
+	results, err := aggregateTokens(mc, tokens, methodDecimals)
 	if err != nil {
 		return nil, err
 	}
 
-	data = append(data, param...)
+	decimals := make([]*big.Int, 0, len(results))
+	for i, r := range results {
+		if !r.Success {
+			return nil, fmt.Errorf("%s decimals(): %w", tokens[i], r.Err)
+		}
+		decimals = append(decimals, contract.ParseInt(r.ReturnData))
+	}
 
-	ct := &core.TriggerSmartContract{
-		OwnerAddress:    ks.Address.Bytes(),
-		ContractAddress: t.ContractAddress.Bytes(),
-		Data:            data,
+	return decimals, nil
+}
+
+// GetSymbolsBatch returns the symbols of tokens with one aggregated call per batch of mc
+func GetSymbolsBatch(mc *multicall.Multicall, tokens []string) ([]string, error) {
+	results, err := aggregateTokens(mc, tokens, methodSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, 0, len(results))
+	for i, r := range results {
+		if !r.Success {
+			return nil, fmt.Errorf("%s symbol(): %w", tokens[i], r.Err)
+		}
+
+		symbol, err := contract.ParseString(r.ReturnData)
+		if err != nil {
+			return nil, fmt.Errorf("%s symbol(): %v", tokens[i], err)
+		}
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols, nil
+}
+
+// aggregateTokens calls method without arguments on each of tokens
+func aggregateTokens(mc *multicall.Multicall, tokens []string, method string) ([]multicall.Result, error) {
+	data, err := common.Hex2Bytes(method)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]multicall.Call, 0, len(tokens))
+	for _, token := range tokens {
+		addr, err := address.Base58ToAddress(token)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, multicall.Call{Target: addr, Data: data})
+	}
+
+	return mc.Aggregate(calls)
+}
+
+func (t *TRC20) Approve(ks keystore.Signer, spender string, amount *big.Int) (*transaction.Transaction, error) {
+	tx, err := t.BuildApprove(ks.GetAddress().String(), spender, amount)
+	if err != nil {
+		return nil, err
 	}
 
-	return t.call(ks, ct)
+	return t.SignAndBroadcast(ks, tx)
 }
 
-func (t *TRC20) Transfer(ks *keystore.Keystore, to string, amount *big.Int) (*transaction.Transaction, error) {
+// BuildApprove returns the unsigned approve transaction of the from account
+func (t *TRC20) BuildApprove(from, spender string, amount *big.Int) (*api.TransactionExtention, error) {
 	param, err := abi.GetParams([]abi.Param{
-		{"address": to},
+		{"address": spender},
 		{"uint256": amount},
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := common.Hex2Bytes(methodTransfer)
+	return t.build(from, methodApprove, param)
+}
+
+func (t *TRC20) Transfer(ks keystore.Signer, to string, amount *big.Int) (*transaction.Transaction, error) {
+	if t.Simulate {
+		if _, err := t.SimulateTransfer(ks.GetAddress().String(), to, amount); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := t.BuildTransfer(ks.GetAddress().String(), to, amount)
 	if err != nil {
 		return nil, err
 	}
 
-	data = append(data, param...)
+	return t.SignAndBroadcast(ks, tx)
+}
 
-	ct := &core.TriggerSmartContract{
-		OwnerAddress:    ks.Address.Bytes(),
-		ContractAddress: t.ContractAddress.Bytes(),
-		Data:            data,
+// BuildTransfer returns the unsigned transfer transaction of the from account
+func (t *TRC20) BuildTransfer(from, to string, amount *big.Int) (*api.TransactionExtention, error) {
+	param, err := abi.GetParams([]abi.Param{
+		{"address": to},
+		{"uint256": amount},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t.build(from, methodTransfer, param)
+}
+
+func (t *TRC20) TransferFrom(ks keystore.Signer, from, to string, amount *big.Int) (*transaction.Transaction, error) {
+	if t.Simulate {
+		if _, err := t.SimulateTransferFrom(ks.GetAddress().String(), from, to, amount); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := t.BuildTransferFrom(ks.GetAddress().String(), from, to, amount)
+	if err != nil {
+		return nil, err
 	}
 
-	return t.call(ks, ct)
+	return t.SignAndBroadcast(ks, tx)
 }
 
-func (t *TRC20) TransferFrom(ks *keystore.Keystore, from, to string, amount *big.Int) (*transaction.Transaction, error) {
+// BuildTransferFrom returns the unsigned transferFrom transaction of the spender account
+func (t *TRC20) BuildTransferFrom(spender, from, to string, amount *big.Int) (*api.TransactionExtention, error) {
 	param, err := abi.GetParams([]abi.Param{
 		{"address": from},
 		{"address": to},
@@ -210,33 +337,53 @@ func (t *TRC20) TransferFrom(ks *keystore.Keystore, from, to string, amount *big
 		return nil, err
 	}
 
-	data, err := common.Hex2Bytes(methodTransferFrom)
+	return t.build(spender, methodTransferFrom, param)
+}
+
+// SimulateTransfer runs the transfer of the from account without broadcasting it
+func (t *TRC20) SimulateTransfer(from, to string, amount *big.Int) (*client.Simulation, error) {
+	param, err := abi.GetParams([]abi.Param{
+		{"address": to},
+		{"uint256": amount},
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	data = append(data, param...)
+	return t.simulate(from, methodTransfer, param)
+}
 
-	ct := &core.TriggerSmartContract{
-		OwnerAddress:    ks.Address.Bytes(),
-		ContractAddress: t.ContractAddress.Bytes(),
-		Data:            data,
+// SimulateTransferFrom runs the transferFrom of the spender account without broadcasting it
+func (t *TRC20) SimulateTransferFrom(spender, from, to string, amount *big.Int) (*client.Simulation, error) {
+	param, err := abi.GetParams([]abi.Param{
+		{"address": from},
+		{"address": to},
+		{"uint256": amount},
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return t.call(ks, ct)
+	return t.simulate(spender, methodTransferFrom, param)
 }
 
-func (t *TRC20) Call(ks *keystore.Keystore, method string, params []byte) (*transaction.Transaction, error) {
-	signature := abi.MethodSignature(method)
-	data := append(signature, params...)
+func (t *TRC20) Call(ks keystore.Signer, method string, params []byte) (*transaction.Transaction, error) {
+	tx, err := t.BuildCall(ks.GetAddress().String(), method, params)
+	if err != nil {
+		return nil, err
+	}
 
-	ct := &core.TriggerSmartContract{
-		OwnerAddress:    ks.Address.Bytes(),
-		ContractAddress: t.ContractAddress.Bytes(),
-		Data:            data,
+	return t.SignAndBroadcast(ks, tx)
+}
+
+// BuildCall returns the unsigned transaction calling method from the from account
+func (t *TRC20) BuildCall(from, method string, params []byte) (*api.TransactionExtention, error) {
+	ct, err := client.NewTriggerSmartContract(from, t.ContractAddress.String(), method, params, 0, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return t.call(ks, ct)
+	return t.BuildContractTransaction(ct, feeLimit)
 }
 
 func (t *TRC20) CallConstant(method string, params []byte) (string, error) {
@@ -259,51 +406,61 @@ func (t *TRC20) callConstant(data []byte) (*api.TransactionExtention, error) {
 		Data:            data,
 	}
 
-	ctx, cancel := t.GrpcClient.GetContext()
-	defer cancel()
-
-	result, err := t.Client.TriggerConstantContract(ctx, ct)
+	result, err := t.TriggerConstantSmartContract(ct)
 	if err != nil {
 		return nil, err
 	}
 
-	if result.Result.Code > 0 {
-		return nil, errors.New(string(result.Result.Message))
+	if len(result.GetConstantResult()) == 0 {
+		return nil, errors.New("empty constant result")
 	}
 
 	return result, nil
 }
 
-func (t *TRC20) call(ks *keystore.Keystore, ct *core.TriggerSmartContract) (*transaction.Transaction, error) {
-	ctx, cancel := t.GetContext()
-	defer cancel()
-
-	tx, err := t.Client.TriggerContract(ctx, ct)
+// build returns the unsigned transaction calling the method selector with param from the from account
+func (t *TRC20) build(from, selector string, param []byte) (*api.TransactionExtention, error) {
+	ct, err := t.triggerSmartContract(from, selector, param)
 	if err != nil {
 		return nil, err
 	}
 
-	if tx.Result.Code > 0 {
-		return nil, fmt.Errorf("%s", string(tx.Result.Message))
+	return t.BuildContractTransaction(ct, feeLimit)
+}
+
+func (t *TRC20) simulate(from, selector string, param []byte) (*client.Simulation, error) {
+	ct, err := t.triggerSmartContract(from, selector, param)
+	if err != nil {
+		return nil, err
 	}
 
-	tx.Transaction.RawData.FeeLimit = feeLimit
-	if err := transaction.UpdateTxHash(tx); err != nil {
+	simulation, err := t.SimulateContract(ct)
+	if err != nil {
 		return nil, err
 	}
 
-	signedTx, err := ks.SignTx(tx.Transaction)
+	// tokens returning false instead of reverting
+	if len(simulation.Result) == 32 && contract.ParseInt(simulation.Result).Sign() == 0 {
+		return nil, errors.New("simulation failed: transfer returned false")
+	}
+
+	return simulation, nil
+}
+
+func (t *TRC20) triggerSmartContract(from, selector string, param []byte) (*core.TriggerSmartContract, error) {
+	owner, err := address.Base58ToAddress(from)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := t.Broadcast(signedTx)
+	data, err := common.Hex2Bytes(selector)
 	if err != nil {
 		return nil, err
 	}
 
-	return &transaction.Transaction{
-		TransactionHash: common.Bytes2Hex(tx.GetTxid()),
-		Result:          result,
+	return &core.TriggerSmartContract{
+		OwnerAddress:    owner.Bytes(),
+		ContractAddress: t.ContractAddress.Bytes(),
+		Data:            append(data, param...),
 	}, nil
 }
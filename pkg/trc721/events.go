@@ -0,0 +1,113 @@
+package trc721
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/transaction"
+)
+
+// TransferEvent is the Transfer(address,address,uint256) event
+type TransferEvent struct {
+	From    address.Address
+	To      address.Address
+	TokenID *big.Int
+	Raw     *abi.Event
+}
+
+// ApprovalEvent is the Approval(address,address,uint256) event
+type ApprovalEvent struct {
+	Owner    address.Address
+	Approved address.Address
+	TokenID  *big.Int
+	Raw      *abi.Event
+}
+
+// ApprovalForAllEvent is the ApprovalForAll(address,address,bool) event
+type ApprovalForAllEvent struct {
+	Owner    address.Address
+	Operator address.Address
+	Approved bool
+	Raw      *abi.Event
+}
+
+// ParseTransfer decodes a Transfer log, abi.ErrEventNotFound is returned for other logs
+func ParseTransfer(log transaction.Log) (*TransferEvent, error) {
+	event, err := decode(log, "Transfer")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TransferEvent{Raw: event}
+	if err := event.Scan(&result.From, &result.To, &result.TokenID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ParseApproval decodes an Approval log, abi.ErrEventNotFound is returned for other logs
+func ParseApproval(log transaction.Log) (*ApprovalEvent, error) {
+	event, err := decode(log, "Approval")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApprovalEvent{Raw: event}
+	if err := event.Scan(&result.Owner, &result.Approved, &result.TokenID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ParseApprovalForAll decodes an ApprovalForAll log, abi.ErrEventNotFound is returned for other logs
+func ParseApprovalForAll(log transaction.Log) (*ApprovalForAllEvent, error) {
+	event, err := decode(log, "ApprovalForAll")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApprovalForAllEvent{Raw: event}
+	if err := event.Scan(&result.Owner, &result.Operator, &result.Approved); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FilterTransfers returns the Transfer events of the receipt emitted by the contract
+func (t *TRC721) FilterTransfers(receipt *transaction.TransactionReceipt) ([]*TransferEvent, error) {
+	events := make([]*TransferEvent, 0)
+	for _, log := range receipt.Logs {
+		if !bytes.Equal(log.Address, t.Address) {
+			continue
+		}
+
+		event, err := ParseTransfer(log)
+		if errors.Is(err, abi.ErrEventNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func decode(log transaction.Log, name string) (*abi.Event, error) {
+	event, err := log.Decode(abi.TRC721)
+	if err != nil {
+		return nil, err
+	}
+	if event.Name != name {
+		return nil, abi.ErrEventNotFound
+	}
+
+	return event, nil
+}
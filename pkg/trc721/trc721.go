@@ -0,0 +1,266 @@
+package trc721
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/contract"
+	"github.com/craftto/go-tron/pkg/keystore"
+	"github.com/craftto/go-tron/pkg/multicall"
+	"github.com/craftto/go-tron/pkg/transaction"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ERC165 interface ids, see contract.InterfaceIDERC165
+var (
+	InterfaceIDTRC721     = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	InterfaceIDMetadata   = [4]byte{0x5b, 0x5e, 0x13, 0x9f}
+	InterfaceIDEnumerable = [4]byte{0x78, 0x0e, 0x9d, 0x63}
+)
+
+// ErrNotEnumerable is returned when the contract does not support TRC721Enumerable
+var ErrNotEnumerable = errors.New("contract does not support TRC721Enumerable")
+
+type TRC721 struct {
+	*contract.Contract
+}
+
+func NewTrc721(g *client.GrpcClient, contractAddr string) (*TRC721, error) {
+	c, err := contract.NewWithABI(g, contractAddr, abi.TRC721)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TRC721{Contract: c}, nil
+}
+
+// IsTRC721 reports whether the contract declares the TRC721 interface,
+// see contract.Contract.SupportsInterface
+func (t *TRC721) IsTRC721() (bool, error) {
+	return t.SupportsInterface(InterfaceIDTRC721)
+}
+
+// IsEnumerable reports whether the contract declares the TRC721Enumerable interface
+func (t *TRC721) IsEnumerable() (bool, error) {
+	return t.SupportsInterface(InterfaceIDEnumerable)
+}
+
+func (t *TRC721) GetName() (string, error) {
+	out, err := t.Contract.Call("name")
+	if err != nil {
+		return "", err
+	}
+
+	return contract.SingleOutput[string](out)
+}
+
+func (t *TRC721) GetSymbol() (string, error) {
+	out, err := t.Contract.Call("symbol")
+	if err != nil {
+		return "", err
+	}
+
+	return contract.SingleOutput[string](out)
+}
+
+func (t *TRC721) GetTokenURI(tokenID *big.Int) (string, error) {
+	out, err := t.Contract.Call("tokenURI", tokenID)
+	if err != nil {
+		return "", err
+	}
+
+	return contract.SingleOutput[string](out)
+}
+
+// GetBalance returns the number of tokens owned by owner
+func (t *TRC721) GetBalance(owner string) (*big.Int, error) {
+	out, err := t.Contract.Call("balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.SingleOutput[*big.Int](out)
+}
+
+func (t *TRC721) GetOwner(tokenID *big.Int) (address.Address, error) {
+	out, err := t.Contract.Call("ownerOf", tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.SingleOutput[address.Address](out)
+}
+
+func (t *TRC721) GetApproved(tokenID *big.Int) (address.Address, error) {
+	out, err := t.Contract.Call("getApproved", tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.SingleOutput[address.Address](out)
+}
+
+func (t *TRC721) IsApprovedForAll(owner, operator string) (bool, error) {
+	out, err := t.Contract.Call("isApprovedForAll", owner, operator)
+	if err != nil {
+		return false, err
+	}
+
+	return contract.SingleOutput[bool](out)
+}
+
+func (t *TRC721) GetTotalSupply() (*big.Int, error) {
+	out, err := t.Contract.Call("totalSupply")
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.SingleOutput[*big.Int](out)
+}
+
+func (t *TRC721) TokenByIndex(index *big.Int) (*big.Int, error) {
+	out, err := t.Contract.Call("tokenByIndex", index)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.SingleOutput[*big.Int](out)
+}
+
+func (t *TRC721) TokenOfOwnerByIndex(owner string, index *big.Int) (*big.Int, error) {
+	out, err := t.Contract.Call("tokenOfOwnerByIndex", owner, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.SingleOutput[*big.Int](out)
+}
+
+// GetTokens returns all the token ids of an enumerable contract,
+// queried with one aggregated call per batch of mc
+func (t *TRC721) GetTokens(mc *multicall.Multicall) ([]*big.Int, error) {
+	if err := t.checkEnumerable(); err != nil {
+		return nil, err
+	}
+
+	total, err := t.GetTotalSupply()
+	if err != nil {
+		return nil, err
+	}
+
+	return t.enumerate(mc, total, "tokenByIndex")
+}
+
+// GetTokensOfOwner returns the token ids owned by owner on an enumerable contract,
+// queried with one aggregated call per batch of mc
+func (t *TRC721) GetTokensOfOwner(mc *multicall.Multicall, owner string) ([]*big.Int, error) {
+	if err := t.checkEnumerable(); err != nil {
+		return nil, err
+	}
+
+	balance, err := t.GetBalance(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.enumerate(mc, balance, "tokenOfOwnerByIndex", owner)
+}
+
+func (t *TRC721) Approve(ks keystore.Signer, to string, tokenID *big.Int) (*transaction.Transaction, error) {
+	return t.Contract.Transact(ks, "approve", to, tokenID)
+}
+
+func (t *TRC721) SetApprovalForAll(ks keystore.Signer, operator string, approved bool) (*transaction.Transaction, error) {
+	return t.Contract.Transact(ks, "setApprovalForAll", operator, approved)
+}
+
+func (t *TRC721) TransferFrom(ks keystore.Signer, from, to string, tokenID *big.Int) (*transaction.Transaction, error) {
+	return t.Contract.Transact(ks, "transferFrom", from, to, tokenID)
+}
+
+// SafeTransferFrom transfers tokenID, a receiving contract must accept it.
+// data is passed to the onTRC721Received hook of the receiver when not nil
+func (t *TRC721) SafeTransferFrom(ks keystore.Signer, from, to string, tokenID *big.Int, data []byte) (*transaction.Transaction, error) {
+	if data == nil {
+		return t.Contract.Transact(ks, "safeTransferFrom(address,address,uint256)", from, to, tokenID)
+	}
+
+	return t.Contract.Transact(ks, "safeTransferFrom(address,address,uint256,bytes)", from, to, tokenID, data)
+}
+
+func (t *TRC721) checkEnumerable() error {
+	enumerable, err := t.IsEnumerable()
+	if err != nil {
+		return err
+	}
+	if !enumerable {
+		return ErrNotEnumerable
+	}
+
+	return nil
+}
+
+// enumerateChunk is the number of indexes aggregated at once, the count comes
+// from the contract and is not trusted for the allocations
+const enumerateChunk = 1024
+
+// enumerate calls method with args followed by each index below count
+func (t *TRC721) enumerate(mc *multicall.Multicall, count *big.Int, method string, args ...interface{}) ([]*big.Int, error) {
+	if !count.IsInt64() || count.Sign() < 0 {
+		return nil, fmt.Errorf("invalid token count %s", count)
+	}
+
+	n := count.Int64()
+	size := n
+	if size > enumerateChunk {
+		size = enumerateChunk
+	}
+
+	tokens := make([]*big.Int, 0, size)
+	calls := make([]multicall.Call, 0, size)
+	var m *ethabi.Method
+
+	for start := int64(0); start < n; start += enumerateChunk {
+		calls = calls[:0]
+		for i := start; i < n && i < start+enumerateChunk; i++ {
+			var (
+				packed []byte
+				err    error
+			)
+			m, packed, err = t.Method(method, append(args, big.NewInt(i))...)
+			if err != nil {
+				return nil, err
+			}
+
+			data := make([]byte, 0, len(m.ID)+len(packed))
+			calls = append(calls, multicall.Call{Target: t.Address, Data: append(append(data, m.ID...), packed...)})
+		}
+
+		results, err := mc.Aggregate(calls)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, r := range results {
+			if !r.Success {
+				return nil, fmt.Errorf("%s(%d): %w", method, start+int64(i), r.Err)
+			}
+
+			out, err := contract.DecodeArguments(m.Outputs, r.ReturnData)
+			if err != nil {
+				return nil, fmt.Errorf("%s(%d): %v", method, start+int64(i), err)
+			}
+			token, err := contract.SingleOutput[*big.Int](out)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens, nil
+}
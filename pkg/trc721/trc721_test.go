@@ -0,0 +1,222 @@
+package trc721
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/craftto/go-tron/pkg/abi"
+	"github.com/craftto/go-tron/pkg/address"
+	"github.com/craftto/go-tron/pkg/client"
+	"github.com/craftto/go-tron/pkg/contract"
+	"github.com/craftto/go-tron/pkg/multicall"
+	"github.com/craftto/go-tron/pkg/proto/api"
+	"github.com/craftto/go-tron/pkg/proto/core"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+)
+
+const (
+	tokenAddr     = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	multicallAddr = "TKPmvcPZmPHHMYqWh6H1c458mBf2ozQnx9"
+	ownerAddr     = "TTh2wKxu8iUCsUsZD1mySpPocF329XBHWg"
+)
+
+// stubNode is an enumerable TRC721 contract holding supply tokens, with
+// the tokens 100+i by index and 200+i by index of the owner
+type stubNode struct {
+	api.WalletClient
+
+	supply, balance int64
+	multicall       address.Address
+	tryAggregate    ethabi.Method
+
+	mu      sync.Mutex
+	batches []int
+	calls   int
+}
+
+func newStubNode(t *testing.T, supply, balance int64) *stubNode {
+	t.Helper()
+
+	mc, err := abi.ParseJSON(multicall.Multicall2ABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := address.Parse(multicallAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &stubNode{
+		supply:       supply,
+		balance:      balance,
+		multicall:    addr,
+		tryAggregate: mc.Methods["tryAggregate"],
+	}
+}
+
+func (n *stubNode) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	if !bytes.Equal(in.ContractAddress, n.multicall) {
+		n.mu.Lock()
+		n.calls++
+		n.mu.Unlock()
+
+		ret, err := n.call(in.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{ret}}, nil
+	}
+
+	inputs, err := n.tryAggregate.Inputs.Unpack(in.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		Success    bool
+		ReturnData []byte
+	}
+
+	calls := reflect.ValueOf(inputs[1])
+	results := make([]result, 0, calls.Len())
+	for i := 0; i < calls.Len(); i++ {
+		ret, err := n.call(calls.Index(i).Field(1).Bytes())
+		results = append(results, result{Success: err == nil, ReturnData: ret})
+	}
+
+	n.mu.Lock()
+	n.batches = append(n.batches, calls.Len())
+	n.mu.Unlock()
+
+	packed, err := n.tryAggregate.Outputs.Pack(results)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{packed}}, nil
+}
+
+func (n *stubNode) call(data []byte) ([]byte, error) {
+	word := func(v int64) []byte { return ethcommon.LeftPadBytes(big.NewInt(v).Bytes(), 32) }
+	arg := func(i int) int64 { return new(big.Int).SetBytes(data[4+32*i : 4+32*(i+1)]).Int64() }
+
+	switch selector := ethcommon.Bytes2Hex(data[:4]); selector {
+	case "01ffc9a7":
+		switch [4]byte{data[4], data[5], data[6], data[7]} {
+		case InterfaceIDEnumerable, contract.InterfaceIDERC165:
+			return word(1), nil
+		}
+		return word(0), nil
+	case "18160ddd":
+		return word(n.supply), nil
+	case "70a08231":
+		return word(n.balance), nil
+	case "4f6ccce7":
+		if arg(0) >= n.supply {
+			return nil, errors.New("index out of bounds")
+		}
+		return word(100 + arg(0)), nil
+	case "2f745c59":
+		if arg(1) >= n.balance {
+			return nil, errors.New("index out of bounds")
+		}
+		return word(200 + arg(1)), nil
+	default:
+		return nil, errors.New("unknown selector " + selector)
+	}
+}
+
+func newTRC721(t *testing.T, node *stubNode) (*TRC721, *multicall.Multicall) {
+	t.Helper()
+
+	g := &client.GrpcClient{Client: node}
+	nft, err := NewTrc721(g, tokenAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc, err := multicall.New(g, multicallAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc.BatchSize = 4
+
+	return nft, mc
+}
+
+func tokenIDs(first, n int64) []*big.Int {
+	ids := make([]*big.Int, 0, n)
+	for i := int64(0); i < n; i++ {
+		ids = append(ids, big.NewInt(first+i))
+	}
+	return ids
+}
+
+func TestGetTokens(t *testing.T) {
+	node := newStubNode(t, 10, 0)
+	nft, mc := newTRC721(t, node)
+
+	tokens, err := nft.GetTokens(mc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tokens, tokenIDs(100, 10)) {
+		t.Errorf("GetTokens() = %v", tokens)
+	}
+
+	// the indexes are aggregated, only the three ERC165 checks and the supply are called directly
+	if !reflect.DeepEqual(node.batches, []int{4, 4, 2}) {
+		t.Errorf("batches = %v, want [4 4 2]", node.batches)
+	}
+	if node.calls != 4 {
+		t.Errorf("%d individual calls, want 4", node.calls)
+	}
+}
+
+func TestGetTokensOfOwner(t *testing.T) {
+	node := newStubNode(t, 10, 3)
+	nft, mc := newTRC721(t, node)
+
+	tokens, err := nft.GetTokensOfOwner(mc, ownerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tokens, tokenIDs(200, 3)) {
+		t.Errorf("GetTokensOfOwner() = %v", tokens)
+	}
+	if !reflect.DeepEqual(node.batches, []int{3}) {
+		t.Errorf("batches = %v, want [3]", node.batches)
+	}
+}
+
+func TestGetTokensEmpty(t *testing.T) {
+	node := newStubNode(t, 0, 0)
+	nft, mc := newTRC721(t, node)
+
+	tokens, err := nft.GetTokens(mc)
+	if err != nil || len(tokens) != 0 {
+		t.Errorf("GetTokens() = %v, %v", tokens, err)
+	}
+	if len(node.batches) != 0 {
+		t.Errorf("batches = %v, want none", node.batches)
+	}
+}
+
+func TestEnumerateFailedCall(t *testing.T) {
+	node := newStubNode(t, 2, 0)
+	nft, mc := newTRC721(t, node)
+
+	// the supply announces more tokens than the contract holds
+	if _, err := nft.enumerate(mc, big.NewInt(3), "tokenByIndex"); err == nil {
+		t.Error("enumerate() accepted a failed call")
+	}
+	if _, err := nft.enumerate(mc, big.NewInt(-1), "tokenByIndex"); err == nil {
+		t.Error("enumerate() accepted a negative count")
+	}
+}